@@ -0,0 +1,24 @@
+package conditions
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/rules"
+)
+
+// Clock supplies the current time to the manager, for condition transition times and grace periods.
+// It is the clock of the rules' age matchers, one fake clock can drive both in tests.
+type Clock = rules.Clock
+
+type realClock struct{}
+
+func (realClock) Now() metav1.Time {
+	return metav1.Now()
+}
+
+// WithClock replaces the real time clock, e.g. with a fixed one in tests.
+func WithClock(clock Clock) Option {
+	return func(m *ConditionsManager) {
+		m.clock = clock
+	}
+}
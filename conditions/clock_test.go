@@ -0,0 +1,54 @@
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fakeClock is a Clock that only moves when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() metav1.Time { return metav1.NewTime(c.now) }
+
+func (c *fakeClock) Step(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestWithClock_LastTransitionTime(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC)
+	clock := &fakeClock{now: now}
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules, WithClock(clock))
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Done", "a is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	clock.Step(time.Hour)
+
+	if err := m.SetConditions(ctx, []Condition{{Type: "B", Status: metav1.ConditionFalse, Reason: "Broken"}}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	var patched testObject
+	if err := json.Unmarshal(statusClient.patches[len(statusClient.patches)-1], &patched); err != nil {
+		t.Fatalf("unmarshal patch: %v", err)
+	}
+
+	for conditionType, want := range map[string]time.Time{"A": now, "B": now.Add(time.Hour)} {
+		condition := meta.FindStatusCondition(patched.Status.Conditions, conditionType)
+		if condition == nil {
+			t.Fatalf("patch is missing condition %s", conditionType)
+		}
+		if !condition.LastTransitionTime.Time.Equal(want) {
+			t.Errorf("condition %s LastTransitionTime = %v, want the clock's %v", conditionType, condition.LastTransitionTime, want)
+		}
+	}
+}
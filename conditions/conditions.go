@@ -2,9 +2,20 @@ package conditions
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -24,22 +35,212 @@ type Object2 interface {
 	SetObservedGeneration(generation int64)
 }
 
+// ErrNoConditions is returned by the manager's methods when it was given a nil conditions pointer and found no
+// []metav1.Condition field on the object to use instead.
+var ErrNoConditions = errors.New("no conditions to manage: the conditions pointer is nil and the object has no []metav1.Condition field")
+
+// ConditionsManager is safe for concurrent use, calls are serialized, from setting conditions to patching status.
+// The object and its conditions must not be modified elsewhere while the manager is in use.
 type ConditionsManager struct {
+	// mu guards the object and its conditions, through the status patch
+	mu sync.Mutex
+
 	conditions   *[]metav1.Condition
-	object       Object2
-	phaseRules   []rules.PhaseRule
+	object       client.Object
+	computer     *rules.PhaseComputer
 	statusClient client.StatusClient
+
+	getPhase              func(client.Object) string
+	setPhase              func(client.Object, string)
+	setObservedGeneration func(client.Object, int64)
+
+	summaryConditionType string
+	summaryGoodPhases    []string
+
+	clock              Clock
+	unknownGracePeriod time.Duration
+
+	sortConditions bool
+
+	onlyStatusChanges bool
+
+	composeMessage MessageComposer
+
+	beforePatch func(obj client.Object)
+
+	tracerProvider trace.TracerProvider
+
+	meterProvider metric.MeterProvider
+
+	overrideAnnotations bool
+
+	freshConditionsOnly bool
+
+	conflictReader  client.Reader
+	conflictBackoff wait.Backoff
+
+	recorder record.EventRecorder
+
+	onPhaseChange func(ctx context.Context, previous, phase string, forced bool) error
+
+	// forcing is set while ForcePhase patches, the phase change is reported as forced
+	forcing bool
+}
+
+// Option configures a ConditionsManager.
+type Option func(*ConditionsManager)
+
+// WithSummaryCondition makes the manager maintain a condition of conditionType summarizing the phase,
+// True while the phase is one of goodPhases and False otherwise, e.g. a top-level "Ready" condition. Its reason is
+// the phase made a valid condition reason, "Not Ready" becomes "NotReady", unless the rule has a Summary.
+// The summary condition is written after the phase is computed, the phase rules are evaluated without it.
+func WithSummaryCondition(conditionType string, goodPhases ...string) Option {
+	return func(m *ConditionsManager) {
+		m.summaryConditionType = conditionType
+		m.summaryGoodPhases = goodPhases
+	}
+}
+
+// WithUnknownGracePeriod keeps the previous, known, phase while no rule is satisfied, until the
+// conditions have gone the grace period without a status transition. This rides out brief blips, e.g. conditions
+// going Unknown while an API is unreachable. Unknown is only committed when conditions are next set after the grace
+// period, changed or not, requeue the object accordingly.
+func WithUnknownGracePeriod(gracePeriod time.Duration) Option {
+	return func(m *ConditionsManager) {
+		m.unknownGracePeriod = gracePeriod
+	}
+}
+
+// WithSortedConditions keeps the conditions sorted by type before every status patch, so the serialized status
+// doesn't depend on the order conditions were first set in, e.g. for GitOps tooling diffing status.
+func WithSortedConditions() Option {
+	return func(m *ConditionsManager) {
+		m.sortConditions = true
+	}
+}
+
+// WithOnlyPatchOnStatusChange ignores updates to a condition that keep its status, and its observed generation, so
+// changes to only its reason or message don't patch status, e.g. for controllers updating messages frequently. The
+// condition keeps its previous reason and message until its status changes. Nothing changed, the phase isn't
+// recomputed either: it only follows status transitions, rules reading reasons, like ConditionReasonIs, see the
+// reason set with the last status transition.
+func WithOnlyPatchOnStatusChange() Option {
+	return func(m *ConditionsManager) {
+		m.onlyStatusChanges = true
+	}
+}
+
+// WithPhaseAccessors reads and writes the object's phase with get and set instead of the Object2 methods,
+// for objects that store the phase under a different field or elsewhere, e.g. in a label.
+func WithPhaseAccessors(get func(client.Object) string, set func(client.Object, string)) Option {
+	return func(m *ConditionsManager) {
+		m.getPhase = get
+		m.setPhase = set
+	}
+}
+
+// WithPhaseSetter calls set with each phase the manager computes, e.g. to keep it in a label or outside the object.
+// The manager can't read the phase back, so phase change events and WithOnPhaseChange, which compare the phase
+// before and after, and WithUnknownGracePeriod, which keeps the previous phase, need WithPhaseAccessors instead.
+func WithPhaseSetter(set func(phase string)) Option {
+	return func(m *ConditionsManager) {
+		m.setPhase = func(_ client.Object, phase string) { set(phase) }
+	}
+}
+
+// WithOnPhaseChange calls onChange with the previous and the new phase after each status patch that changed the
+// phase, e.g. to update an external system, forced if ForcePhase set it. Its error is returned from the call that
+// patched, the patch stays. The callback runs while the manager is locked, it must not call the manager.
+func WithOnPhaseChange(onChange func(ctx context.Context, previous, phase string, forced bool) error) Option {
+	return func(m *ConditionsManager) {
+		m.onPhaseChange = onChange
+	}
+}
+
+// WithDefaultPhase sets the phase when no rule is satisfied, e.g. "Pending", instead of PhaseUnknown.
+func WithDefaultPhase(phase string) Option {
+	return func(m *ConditionsManager) {
+		m.computer = rules.NewPhaseComputerWithDefault(phase, m.computer.Rules()...)
+	}
+}
+
+// WithBeforePatch calls hook with the object after the phase is computed and right before each status patch,
+// so derived fields it sets land in the same patch. The patch goes to the status subresource, the API server
+// only persists status changes from it. The hook runs while the manager is locked, it must not call the manager.
+func WithBeforePatch(hook func(obj client.Object)) Option {
+	return func(m *ConditionsManager) {
+		m.beforePatch = hook
+	}
 }
 
 // we only set status of objects we own, therefore justified to use a different interface than client.Object
 // which means we miss out on core resources
-func NewManager(statusClient client.StatusClient, conditions *[]metav1.Condition, object Object2, rules []rules.PhaseRule) *ConditionsManager {
-	return &ConditionsManager{
+func NewManager(statusClient client.StatusClient, conditions *[]metav1.Condition, object Object2, phaseRules []rules.PhaseRule, opts ...Option) *ConditionsManager {
+	return NewManagerForObject(statusClient, conditions, object, phaseRules, opts...)
+}
+
+// NewManagerForObject is NewManager for objects that don't implement Object2. Pass WithPhaseAccessors to tell the
+// manager where the phase lives, without it the phase is only kept if object has Object2's GetPhase and SetPhase.
+// The observed generation is set if object has a SetObservedGeneration(int64) method.
+func NewManagerForObject(statusClient client.StatusClient, conditions *[]metav1.Condition, object client.Object, phaseRules []rules.PhaseRule, opts ...Option) *ConditionsManager {
+	m := newManager(statusClient, conditions, object, phaseRules)
+
+	// the accessors act on the object passed, a copy of object has the same methods
+	type phased interface {
+		GetPhase() string
+		SetPhase(phase string)
+	}
+
+	if _, ok := object.(phased); ok {
+		m.getPhase = func(obj client.Object) string { return obj.(phased).GetPhase() }
+		m.setPhase = func(obj client.Object, phase string) { obj.(phased).SetPhase(phase) }
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// NewManagerForConditions is NewManager for objects that only carry conditions, e.g. core resources. The phase is
+// computed but not stored on object, pass WithPhaseSetter or WithPhaseAccessors to receive it.
+// The observed generation is set if object has a SetObservedGeneration(int64) method.
+func NewManagerForConditions(statusClient client.StatusClient, conditions *[]metav1.Condition, object client.Object, phaseRules []rules.PhaseRule, opts ...Option) *ConditionsManager {
+	m := newManager(statusClient, conditions, object, phaseRules)
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+func newManager(statusClient client.StatusClient, conditions *[]metav1.Condition, object client.Object, phaseRules []rules.PhaseRule) *ConditionsManager {
+	// an uninitialized status is easy to pass, fall back to the object's own conditions field
+	if conditions == nil {
+		conditions = findConditions(object)
+	}
+
+	m := &ConditionsManager{
 		conditions:   conditions,
 		object:       object,
-		phaseRules:   rules,
+		computer:     rules.NewPhaseComputer(phaseRules...),
 		statusClient: statusClient,
+		clock:        realClock{},
+
+		getPhase:              func(client.Object) string { return "" },
+		setPhase:              func(client.Object, string) {},
+		setObservedGeneration: func(client.Object, int64) {},
+	}
+
+	type observed interface{ SetObservedGeneration(generation int64) }
+
+	if _, ok := object.(observed); ok {
+		m.setObservedGeneration = func(obj client.Object, generation int64) { obj.(observed).SetObservedGeneration(generation) }
 	}
+
+	return m
 }
 
 type Condition struct {
@@ -49,54 +250,242 @@ type Condition struct {
 	Message string
 }
 
-func (m *ConditionsManager) SetConditions(ctx context.Context, conditions []Condition) error {
-	logger := log.FromContext(ctx)
+// SetOption configures a single SetConditions call.
+type SetOption func(*setOptions)
+
+type setOptions struct {
+	skipObservedGeneration bool
+}
+
+// WithoutObservedGeneration leaves the object's observed generation untouched.
+// Use it for a partial batch of conditions that doesn't represent a complete reconcile.
+func WithoutObservedGeneration() SetOption {
+	return func(o *setOptions) {
+		o.skipObservedGeneration = true
+	}
+}
+
+func (m *ConditionsManager) SetConditions(ctx context.Context, conditions []Condition, opts ...SetOption) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conditions == nil {
+		return ErrNoConditions
+	}
+
+	base := m.object.DeepCopyObject().(client.Object)
+
+	apply := func() bool {
+		return m.applyConditions(ctx, conditions, opts...)
+	}
+
+	if apply() {
+		return m.patchStatusWithRetry(ctx, base, apply)
+	}
+
+	return nil
+}
+
+// SetConditionIf sets condition like SetConditions, only if predicate holds for the current conditions, e.g. to only
+// set Degraded while not Ready. The predicate runs before anything is changed, on a copy of the conditions; if it
+// returns false nothing is written or patched.
+func (m *ConditionsManager) SetConditionIf(ctx context.Context, condition Condition, predicate func(current *[]metav1.Condition) bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conditions == nil {
+		return ErrNoConditions
+	}
 
 	base := m.object.DeepCopyObject().(client.Object)
 
-	changed := false
+	apply := func() bool {
+		current := m.copyConditions()
+		if !predicate(&current) {
+			return false
+		}
+
+		return m.applyConditions(ctx, []Condition{condition})
+	}
+
+	if apply() {
+		return m.patchStatusWithRetry(ctx, base, apply)
+	}
+
+	return nil
+}
+
+// applyConditions sets the conditions and, if any changed, recomputes the phase and marks the generation observed,
+// reporting whether anything changed.
+func (m *ConditionsManager) applyConditions(ctx context.Context, conditions []Condition, opts ...SetOption) bool {
+	logger := log.FromContext(ctx)
+
+	options := setOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	changed, affectsPhase := false, false
 
 	for _, condition := range conditions {
-		changed = meta.SetStatusCondition(m.conditions, metav1.Condition{
-			Type:               condition.Type,
-			Status:             condition.Status,
-			Reason:             condition.Reason,
-			Message:            condition.Message,
-			LastTransitionTime: metav1.Now(),
-			ObservedGeneration: m.object.GetGeneration(),
-		})
+		if m.setStatusCondition(condition) {
+			changed = true
+			affectsPhase = affectsPhase || m.affectsPhase(condition.Type)
 
-		if changed {
-			logger.Info("status condition updated", "condition", condition.Type, "status", condition.Status, "reason", condition.Reason, "message", condition.Message, "phase", m.object.GetPhase())
+			logger.Info("status condition updated", "condition", condition.Type, "status", condition.Status, "reason", condition.Reason, "message", condition.Message, "phase", m.getPhase(m.object))
 		}
 	}
 
 	if changed {
-		ruleMatched := false
+		// recompute phase once for the batch, since a condition status has changed
+		if affectsPhase {
+			m.recomputePhase(ctx)
+		}
 
-		// recompute phase, since a condition status has changed
-		for _, rule := range m.phaseRules {
-			if rule.Satisfies(m.conditions) {
-				m.object.SetPhase(rule.Phase())
-				ruleMatched = true
-				break
+		// mark as spec observed and processed, unless the batch is partial
+		if !options.skipObservedGeneration {
+			m.setObservedGeneration(m.object, m.object.GetGeneration())
+		}
+
+		return true
+	}
+
+	// nothing to update, unless a phase held through the grace period is due
+	return m.commitHeldPhase(ctx)
+}
+
+// setStatusCondition sets condition at the object's generation, reporting whether it changed. With
+// WithOnlyPatchOnStatusChange a condition keeping its status and observed generation is left as it is.
+func (m *ConditionsManager) setStatusCondition(condition Condition) bool {
+	if m.onlyStatusChanges {
+		existing := meta.FindStatusCondition(*m.conditions, condition.Type)
+		if existing != nil && existing.Status == condition.Status && existing.ObservedGeneration == m.object.GetGeneration() {
+			return false
+		}
+	}
+
+	return meta.SetStatusCondition(m.conditions, metav1.Condition{
+		Type:               condition.Type,
+		Status:             condition.Status,
+		Reason:             condition.Reason,
+		Message:            condition.Message,
+		LastTransitionTime: m.clock.Now(),
+		ObservedGeneration: m.object.GetGeneration(),
+	})
+}
+
+// phaseReason turns phase into a valid condition reason, CamelCase: the characters a reason can't hold are dropped,
+// capitalizing the letter after them and the first, e.g. "not ready" becomes "NotReady". A phase without a letter is Unknown.
+func phaseReason(phase string) string {
+	var b strings.Builder
+
+	capitalize := true
+
+	for _, r := range phase {
+		letter := ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+
+		switch {
+		case b.Len() == 0 && !letter:
+			// a reason starts with a letter
+		case letter || ('0' <= r && r <= '9') || r == '_':
+			if capitalize && 'a' <= r && r <= 'z' {
+				r -= 'a' - 'A'
 			}
+
+			b.WriteRune(r)
+
+			capitalize = false
+		default:
+			capitalize = true
 		}
+	}
+
+	if b.Len() == 0 {
+		return rules.PhaseUnknown
+	}
+
+	return b.String()
+}
+
+// PreviewPatch returns the status patch SetConditions would send for conditions, without sending it and without
+// changing the object, e.g. for GitOps diffing. It returns nil if nothing would change.
+func (m *ConditionsManager) PreviewPatch(ctx context.Context, conditions []Condition, opts ...SetOption) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conditions == nil {
+		return nil, ErrNoConditions
+	}
+
+	base := m.object.DeepCopyObject().(client.Object)
+	previous := m.copyConditions()
+
+	defer func() {
+		restoreObject(m.object, base)
 
-		if !ruleMatched {
-			m.object.SetPhase(rules.PhaseUnknown)
+		if m.conditions != nil {
+			*m.conditions = previous
 		}
+	}()
 
-		// mark as spec observed and processed
-		m.object.SetObservedGeneration(m.object.GetGeneration())
+	// the preview changes nothing, there's nothing to log
+	if !m.applyConditions(log.IntoContext(ctx, logr.Discard()), conditions, opts...) {
+		return nil, nil
+	}
+
+	m.prepareForPatch()
 
-		return m.statusClient.Status().Patch(ctx, m.object, client.MergeFrom(base))
+	return client.MergeFrom(base).Data(m.object)
+}
+
+// ComputeOnly returns the phase and conditions SetConditions would leave the object with for conditions, without
+// patching status and without changing the object, e.g. for a dry run or to test transitions without a client.
+// If nothing would change, they are the current phase and conditions. The returned conditions are safe to mutate.
+func (m *ConditionsManager) ComputeOnly(ctx context.Context, conditions []Condition, opts ...SetOption) (string, []metav1.Condition) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// nothing is written back, without conditions to manage compute on scratch ones
+	if m.conditions == nil {
+		m.conditions = &[]metav1.Condition{}
+		defer func() { m.conditions = nil }()
 	}
 
-	return nil
+	base := m.object.DeepCopyObject().(client.Object)
+	previous := m.copyConditions()
+
+	defer func() {
+		restoreObject(m.object, base)
+
+		if m.conditions != nil {
+			*m.conditions = previous
+		}
+	}()
+
+	// the dry run changes nothing, there's nothing to log
+	m.applyConditions(log.IntoContext(ctx, logr.Discard()), conditions, opts...)
+
+	m.normalizeConditions()
+
+	return m.getPhase(m.object), m.copyConditions()
 }
 
 func (m *ConditionsManager) SetCondition(ctx context.Context, conditionType string, status metav1.ConditionStatus, reason, message string) error {
+	_, _, err := m.SetConditionAndReport(ctx, conditionType, status, reason, message)
+	return err
+}
+
+// SetConditionAndReport is SetCondition returning the object's phase afterwards and whether the condition changed,
+// e.g. to requeue while the phase is still Unknown. The phase is read back from the object, it is empty with
+// WithPhaseSetter.
+func (m *ConditionsManager) SetConditionAndReport(ctx context.Context, conditionType string, status metav1.ConditionStatus, reason, message string) (string, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conditions == nil {
+		return m.getPhase(m.object), false, ErrNoConditions
+	}
+
 	logger := log.FromContext(ctx)
 
 	/*
@@ -106,36 +495,393 @@ func (m *ConditionsManager) SetCondition(ctx context.Context, conditionType stri
 	 */
 	base := m.object.DeepCopyObject().(client.Object)
 
-	if meta.SetStatusCondition(m.conditions, metav1.Condition{
-		Type:               conditionType,
-		Status:             status,
-		Reason:             reason,
-		Message:            message,
-		LastTransitionTime: metav1.Now(),
-		ObservedGeneration: m.object.GetGeneration(),
-	}) {
-		ruleMatched := false
+	conditionChanged := false
 
-		// recompute phase, since a condition status has changed
-		for _, rule := range m.phaseRules {
-			if rule.Satisfies(m.conditions) {
-				m.object.SetPhase(rule.Phase())
-				ruleMatched = true
-				break
-			}
+	apply := func() bool {
+		conditionChanged = m.setStatusCondition(Condition{Type: conditionType, Status: status, Reason: reason, Message: message})
+		if !conditionChanged {
+			// nothing to update, unless a phase held through the grace period is due
+			return m.commitHeldPhase(ctx)
 		}
 
-		if !ruleMatched {
-			m.object.SetPhase(rules.PhaseUnknown)
+		// recompute phase, since a condition status has changed
+		if m.affectsPhase(conditionType) {
+			m.recomputePhase(ctx)
 		}
 
 		// mark as spec observed and processed
-		m.object.SetObservedGeneration(m.object.GetGeneration())
+		m.setObservedGeneration(m.object, m.object.GetGeneration())
+
+		logger.Info("status condition updated", "condition", conditionType, "status", status, "reason", reason, "message", message, "phase", m.getPhase(m.object))
+
+		return true
+	}
+
+	if !apply() {
+		return m.getPhase(m.object), false, nil
+	}
+
+	err := m.patchStatusWithRetry(ctx, base, apply)
+
+	return m.getPhase(m.object), conditionChanged, err
+}
+
+// RemoveCondition removes the condition of conditionType, then recomputes the phase from the remaining conditions,
+// marks the generation observed and patches status. It does nothing if there is no such condition.
+func (m *ConditionsManager) RemoveCondition(ctx context.Context, conditionType string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conditions == nil {
+		return ErrNoConditions
+	}
+
+	logger := log.FromContext(ctx)
+
+	base := m.object.DeepCopyObject().(client.Object)
+
+	apply := func() bool {
+		if !meta.RemoveStatusCondition(m.conditions, conditionType) {
+			return false
+		}
+
+		// recompute phase, since a condition is gone
+		if m.affectsPhase(conditionType) {
+			m.recomputePhase(ctx)
+		}
+
+		m.setObservedGeneration(m.object, m.object.GetGeneration())
+
+		logger.Info("status condition removed", "condition", conditionType, "phase", m.getPhase(m.object))
+
+		return true
+	}
+
+	if apply() {
+		return m.patchStatusWithRetry(ctx, base, apply)
+	}
+
+	return nil
+}
+
+// Conditions returns a copy of the conditions the manager holds, safe to mutate.
+func (m *ConditionsManager) Conditions() []metav1.Condition {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.copyConditions()
+}
+
+// GetCondition returns a copy of the condition of conditionType, nil if there is none.
+func (m *ConditionsManager) GetCondition(conditionType string) *metav1.Condition {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conditions == nil {
+		return nil
+	}
+
+	condition := meta.FindStatusCondition(*m.conditions, conditionType)
+	if condition == nil {
+		return nil
+	}
+
+	return condition.DeepCopy()
+}
+
+// HasCondition reports whether the condition of conditionType is present with status.
+func (m *ConditionsManager) HasCondition(conditionType string, status metav1.ConditionStatus) bool {
+	condition := m.GetCondition(conditionType)
+
+	return condition != nil && condition.Status == status
+}
+
+func (m *ConditionsManager) copyConditions() []metav1.Condition {
+	if m.conditions == nil || *m.conditions == nil {
+		return nil
+	}
+
+	conditions := make([]metav1.Condition, len(*m.conditions))
+	for i := range *m.conditions {
+		(*m.conditions)[i].DeepCopyInto(&conditions[i])
+	}
+
+	return conditions
+}
+
+// ForcePhase sets the object's phase regardless of the conditions and the phase rules, e.g. for administrative
+// overrides or migrations, then marks the generation observed and patches status. The phase change is recorded as
+// forced, see WithEventRecorder and WithOnPhaseChange. If the object already has the phase nothing is patched.
+// The forced phase only holds until a change to a condition the rules read recomputes the phase from the rules.
+func (m *ConditionsManager) ForcePhase(ctx context.Context, phase string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conditions == nil {
+		return ErrNoConditions
+	}
+
+	logger := log.FromContext(ctx)
+
+	base := m.object.DeepCopyObject().(client.Object)
+
+	apply := func() bool {
+		previous := m.getPhase(m.object)
+		if previous == phase {
+			return false
+		}
+
+		m.applyPhase(phase, nil)
+
+		m.setObservedGeneration(m.object, m.object.GetGeneration())
+
+		logger.Info("phase forced", "previousPhase", previous, "phase", phase, "forced", true)
+
+		return true
+	}
+
+	if !apply() {
+		return nil
+	}
+
+	m.forcing = true
+	defer func() { m.forcing = false }()
+
+	return m.patchStatusWithRetry(ctx, base, apply)
+}
+
+// RecomputePhase evaluates the rules against the current conditions without setting any, e.g. on startup after the
+// rules changed, and returns the resulting phase. Only if the phase changed does it mark the generation observed and
+// patch status; otherwise the object is left as it was. The phase is read back from the object, with WithPhaseSetter
+// it can't be compared and nothing is patched.
+func (m *ConditionsManager) RecomputePhase(ctx context.Context) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.conditions == nil {
+		return m.getPhase(m.object), ErrNoConditions
+	}
+
+	logger := log.FromContext(ctx)
+
+	base := m.object.DeepCopyObject().(client.Object)
+	previousConditions := m.copyConditions()
+
+	apply := func() bool {
+		previous := m.getPhase(m.object)
+
+		m.recomputePhase(ctx)
+
+		if phase := m.getPhase(m.object); phase == previous {
+			return false
+		}
+
+		m.setObservedGeneration(m.object, m.object.GetGeneration())
+
+		logger.Info("phase recomputed", "previousPhase", previous, "phase", m.getPhase(m.object))
+
+		return true
+	}
+
+	if !apply() {
+		restoreObject(m.object, base)
 
-		logger.Info("status condition updated", "condition", conditionType, "status", status, "reason", reason, "message", message, "phase", m.object.GetPhase())
+		if m.conditions != nil {
+			*m.conditions = previousConditions
+		}
+
+		return m.getPhase(m.object), nil
+	}
+
+	err := m.patchStatusWithRetry(ctx, base, apply)
+
+	return m.getPhase(m.object), err
+}
+
+// affectsPhase reports whether a change to the condition of conditionType calls for recomputing the phase.
+// Changes to conditions no rule reads don't, once the object has a phase, unless a grace period or annotations
+// could make the recomputed phase differ regardless.
+func (m *ConditionsManager) affectsPhase(conditionType string) bool {
+	if m.getPhase(m.object) == "" || m.unknownGracePeriod > 0 || m.overrideAnnotations {
+		return true
+	}
+
+	return m.computer.DependsOn(conditionType)
+}
+
+// recomputePhase sets the object's phase from the first satisfied rule, the default phase if none are,
+// unless the object's annotations override it.
+func (m *ConditionsManager) recomputePhase(ctx context.Context) {
+	forced, unmanaged := m.annotatedPhase()
+	if unmanaged {
+		return
+	}
+
+	if forced != "" {
+		m.applyPhase(forced, nil)
+		return
+	}
+
+	result := m.evaluatePhase(ctx)
+
+	if result.Rule == nil && m.withinUnknownGracePeriod() {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+
+	if result.Rule != nil {
+		logger.V(1).Info("phase rule matched", "phase", result.Phase, "index", result.Index, "priority", result.Rule.Priority())
+	} else {
+		// no rule is satisfied, so every rule was tried and has a diagnostic
+		logger.Info("no phase rule matched, falling back to the default phase", "phase", result.Phase, "diagnostics", result.Diagnostics)
+	}
+
+	m.applyPhase(result.Phase, result.Rule)
+}
+
+// evaluatePhase returns the phase of the first rule satisfied by the conditions, along with the rule and its index,
+// or the default phase, nil and -1, with the diagnostics of the rules evaluated.
+func (m *ConditionsManager) evaluatePhase(ctx context.Context) rules.EvaluationResult {
+	var span trace.Span
+	if m.tracerProvider != nil {
+		_, span = m.tracerProvider.Tracer(tracerName).Start(ctx, "ComputePhase")
+		defer span.End()
+	}
+
+	result := m.computer.Evaluate(m.ruleConditions())
+
+	traceEvaluation(span, result.Phase, len(result.Diagnostics))
+
+	return result
+}
+
+// commitHeldPhase recomputes the phase of an object keeping a known phase through WithUnknownGracePeriod, so the
+// default phase is committed once the grace period is over even if the conditions are set again unchanged. It reports
+// whether the phase changed.
+func (m *ConditionsManager) commitHeldPhase(ctx context.Context) bool {
+	if m.unknownGracePeriod <= 0 {
+		return false
+	}
+
+	previous := m.getPhase(m.object)
+	if previous == "" || previous == m.computer.DefaultPhase() || m.withinUnknownGracePeriod() {
+		return false
+	}
+
+	m.recomputePhase(ctx)
+
+	return m.getPhase(m.object) != previous
+}
+
+// withinUnknownGracePeriod reports whether the object has a known phase to keep, and the last condition status
+// transition happened less than the grace period ago.
+func (m *ConditionsManager) withinUnknownGracePeriod() bool {
+	if m.unknownGracePeriod <= 0 {
+		return false
+	}
+
+	if previous := m.getPhase(m.object); previous == "" || previous == m.computer.DefaultPhase() {
+		return false
+	}
 
-		return m.statusClient.Status().Patch(ctx, m.object, client.MergeFrom(base))
+	var lastTransition time.Time
+
+	for _, condition := range *m.conditions {
+		if condition.Type != m.summaryConditionType && condition.LastTransitionTime.After(lastTransition) {
+			lastTransition = condition.LastTransitionTime.Time
+		}
+	}
+
+	return m.clock.Now().Sub(lastTransition) < m.unknownGracePeriod
+}
+
+// patchStatus patches the object's status against base, the object as it was before the update,
+// after normalizing the conditions and running the before patch hook, then records and reports the phase change
+// if any.
+func (m *ConditionsManager) patchStatus(ctx context.Context, base client.Object) error {
+	m.prepareForPatch()
+
+	if err := m.statusClient.Status().Patch(ctx, m.object, client.MergeFrom(base)); err != nil {
+		return err
+	}
+
+	m.recordPhaseChange(base)
+	m.recordPhaseMetrics(ctx, base)
+
+	if previous, phase := m.getPhase(base), m.getPhase(m.object); m.onPhaseChange != nil && previous != phase {
+		return m.onPhaseChange(ctx, previous, phase, m.forcing)
 	}
 
 	return nil
 }
+
+// prepareForPatch normalizes the conditions and runs the before patch hook.
+func (m *ConditionsManager) prepareForPatch() {
+	m.normalizeConditions()
+
+	if m.beforePatch != nil {
+		m.beforePatch(m.object)
+	}
+}
+
+// restoreObject sets obj back to base, a deep copy of it taken earlier.
+func restoreObject(obj, base client.Object) {
+	target := reflect.ValueOf(obj)
+	source := reflect.ValueOf(base)
+
+	if target.Kind() != reflect.Pointer || source.Type() != target.Type() {
+		return
+	}
+
+	target.Elem().Set(source.Elem())
+}
+
+// normalizeConditions sorts the conditions by type if the manager is configured to.
+func (m *ConditionsManager) normalizeConditions() {
+	if !m.sortConditions || m.conditions == nil {
+		return
+	}
+
+	slices.SortStableFunc(*m.conditions, func(a, b metav1.Condition) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+}
+
+// applyPhase sets the object's phase and keeps the summary condition in line with it.
+// rule is the rule that computed the phase, nil if none did.
+func (m *ConditionsManager) applyPhase(phase string, rule rules.PhaseRule) {
+	m.setPhase(m.object, phase)
+
+	if m.summaryConditionType == "" {
+		return
+	}
+
+	status := metav1.ConditionFalse
+	if slices.Contains(m.summaryGoodPhases, phase) {
+		status = metav1.ConditionTrue
+	}
+
+	reason, message := phaseReason(phase), m.phaseMessage(phase, rule)
+
+	// the rule's own summary, if it has one, explains the phase best
+	if summarizer, ok := rule.(rules.Summarizer); ok {
+		summaryReason, summaryMessage := summarizer.Summary()
+
+		if summaryReason != "" {
+			reason = summaryReason
+		}
+		if summaryMessage != "" {
+			message = summaryMessage
+		}
+	}
+
+	meta.SetStatusCondition(m.conditions, metav1.Condition{
+		Type:               m.summaryConditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: m.clock.Now(),
+		ObservedGeneration: m.object.GetGeneration(),
+	})
+}
@@ -0,0 +1,1018 @@
+package conditions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/rules"
+)
+
+type testStatus struct {
+	Phase              string             `json:"phase,omitempty"`
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type testObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status testStatus `json:"status,omitempty"`
+}
+
+var _ Object2 = (*testObject)(nil)
+
+func (o *testObject) DeepCopyObject() runtime.Object {
+	out := *o
+	o.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if o.Status.Conditions != nil {
+		out.Status.Conditions = make([]metav1.Condition, len(o.Status.Conditions))
+		for i := range o.Status.Conditions {
+			o.Status.Conditions[i].DeepCopyInto(&out.Status.Conditions[i])
+		}
+	}
+	return &out
+}
+
+func (o *testObject) SetPhase(phase string) { o.Status.Phase = phase }
+
+func (o *testObject) GetPhase() string { return o.Status.Phase }
+
+func (o *testObject) SetObservedGeneration(generation int64) { o.Status.ObservedGeneration = generation }
+
+func newTestObject(generation int64) *testObject {
+	return &testObject{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: generation},
+	}
+}
+
+// fakeStatusClient records the status patches it receives.
+type fakeStatusClient struct {
+	patches [][]byte
+}
+
+func (c *fakeStatusClient) Status() client.SubResourceWriter {
+	return &fakeStatusWriter{client: c}
+}
+
+type fakeStatusWriter struct {
+	client *fakeStatusClient
+}
+
+func (w *fakeStatusWriter) Create(context.Context, client.Object, client.Object, ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+func (w *fakeStatusWriter) Update(context.Context, client.Object, ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+func (w *fakeStatusWriter) Patch(_ context.Context, obj client.Object, patch client.Patch, _ ...client.SubResourcePatchOption) error {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	w.client.patches = append(w.client.patches, data)
+	return nil
+}
+
+var testRules = []rules.PhaseRule{
+	rules.NewPhaseRule("Ready", rules.ConditionsAll(
+		rules.ConditionEquals("A", metav1.ConditionTrue),
+		rules.ConditionEquals("B", metav1.ConditionTrue),
+	)),
+	rules.NewPhaseRule("Failed", rules.ConditionsAny(
+		rules.ConditionEquals("A", metav1.ConditionFalse),
+		rules.ConditionEquals("B", metav1.ConditionFalse),
+	)),
+}
+
+func TestSetConditions_WithoutObservedGeneration(t *testing.T) {
+	obj := newTestObject(3)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules)
+
+	err := m.SetConditions(context.Background(), []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Done", Message: "a is done"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done", Message: "b is done"},
+	}, WithoutObservedGeneration())
+	if err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	if obj.Status.ObservedGeneration != 0 {
+		t.Errorf("ObservedGeneration = %d, want it left at 0", obj.Status.ObservedGeneration)
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want %q", obj.Status.Phase, "Ready")
+	}
+	if !meta.IsStatusConditionTrue(obj.Status.Conditions, "B") {
+		t.Error("expected condition B to be set to True")
+	}
+	if len(statusClient.patches) != 1 {
+		t.Errorf("got %d patches, want 1", len(statusClient.patches))
+	}
+}
+
+func TestSetConditions_ObservedGenerationByDefault(t *testing.T) {
+	obj := newTestObject(3)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules)
+
+	err := m.SetConditions(context.Background(), []Condition{
+		{Type: "A", Status: metav1.ConditionFalse, Reason: "Broken", Message: "a is broken"},
+	})
+	if err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	if obj.Status.ObservedGeneration != 3 {
+		t.Errorf("ObservedGeneration = %d, want 3", obj.Status.ObservedGeneration)
+	}
+	if obj.Status.Phase != "Failed" {
+		t.Errorf("Phase = %q, want %q", obj.Status.Phase, "Failed")
+	}
+}
+
+func TestSetConditions_EarlierConditionChanged(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules)
+
+	if err := m.SetConditions(ctx, []Condition{
+		{Type: "A", Status: metav1.ConditionFalse, Reason: "Broken"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	// only A transitions, the last condition is unchanged
+	if err := m.SetConditions(ctx, []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Done"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	if len(statusClient.patches) != 2 {
+		t.Errorf("got %d patches, want one per batch, 2", len(statusClient.patches))
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want %q", obj.Status.Phase, "Ready")
+	}
+}
+
+func TestSetConditionIf(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules)
+
+	notReady := func(current *[]metav1.Condition) bool {
+		return !meta.IsStatusConditionTrue(*current, "Ready")
+	}
+
+	if err := m.SetConditionIf(ctx, Condition{Type: "Degraded", Status: metav1.ConditionTrue, Reason: "Slow"}, notReady); err != nil {
+		t.Fatalf("SetConditionIf() error = %v", err)
+	}
+	if !meta.IsStatusConditionTrue(obj.Status.Conditions, "Degraded") || len(statusClient.patches) != 1 {
+		t.Fatalf("expected Degraded set and patched while not Ready, got %v and %d patches", obj.Status.Conditions, len(statusClient.patches))
+	}
+
+	if err := m.SetCondition(ctx, "Ready", metav1.ConditionTrue, "Done", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	if err := m.SetConditionIf(ctx, Condition{Type: "Degraded", Status: metav1.ConditionFalse, Reason: "Fast"}, notReady); err != nil {
+		t.Fatalf("SetConditionIf() error = %v", err)
+	}
+	if !meta.IsStatusConditionTrue(obj.Status.Conditions, "Degraded") {
+		t.Error("Degraded changed although the predicate didn't hold")
+	}
+	if len(statusClient.patches) != 2 {
+		t.Errorf("got %d patches, want none for the skipped write", len(statusClient.patches)-2)
+	}
+}
+
+func TestWithSummaryCondition_FlipsOnGoodPhase(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithSummaryCondition("Available", "Ready"))
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Done", "a is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	summary := meta.FindStatusCondition(obj.Status.Conditions, "Available")
+	if summary == nil {
+		t.Fatal("expected summary condition to be set")
+	}
+	if summary.Status != metav1.ConditionFalse || summary.Reason != rules.PhaseUnknown {
+		t.Errorf("summary = %s/%s, want False/%s while B is missing", summary.Status, summary.Reason, rules.PhaseUnknown)
+	}
+
+	if err := m.SetCondition(ctx, "B", metav1.ConditionTrue, "Done", "b is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	summary = meta.FindStatusCondition(obj.Status.Conditions, "Available")
+	if summary.Status != metav1.ConditionTrue || summary.Reason != "Ready" {
+		t.Errorf("summary = %s/%s, want True/Ready", summary.Status, summary.Reason)
+	}
+}
+
+func TestWithSummaryCondition_PhaseReason(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	phaseRules := []rules.PhaseRule{rules.NewPhaseRule("Not Ready", rules.ConditionEquals("A", metav1.ConditionFalse))}
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, phaseRules, WithSummaryCondition("Available", "Ready"))
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionFalse, "Broken", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if summary := meta.FindStatusCondition(obj.Status.Conditions, "Available"); summary == nil || summary.Reason != "NotReady" {
+		t.Errorf("summary = %v, want reason NotReady for phase %q", summary, "Not Ready")
+	}
+}
+
+func TestPhaseReason(t *testing.T) {
+	reason := regexp.MustCompile(`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`)
+
+	for phase, want := range map[string]string{
+		"Ready":           "Ready",
+		"Not Ready":       "NotReady",
+		"scaling-up":      "ScalingUp",
+		"2 replicas/down": "ReplicasDown",
+		"Backoff_Retry:":  "Backoff_Retry",
+		"":                rules.PhaseUnknown,
+		"--":              rules.PhaseUnknown,
+	} {
+		got := phaseReason(phase)
+		if got != want {
+			t.Errorf("phaseReason(%q) = %q, want %q", phase, got, want)
+		}
+		if !reason.MatchString(got) {
+			t.Errorf("phaseReason(%q) = %q is not a valid condition reason", phase, got)
+		}
+	}
+}
+
+func TestWithSummaryCondition_RuleSummary(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	phaseRules := []rules.PhaseRule{
+		rules.NewPhaseRule("Failed", rules.ConditionEquals("A", metav1.ConditionFalse), rules.WithSummary("ComponentDown", "component A is down")),
+		rules.NewPhaseRule("Ready", rules.ConditionEquals("A", metav1.ConditionTrue)),
+	}
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, phaseRules, WithSummaryCondition("PhaseSummary", "Ready"))
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionFalse, "Crashed", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	summary := meta.FindStatusCondition(obj.Status.Conditions, "PhaseSummary")
+	if summary == nil || summary.Status != metav1.ConditionFalse || summary.Reason != "ComponentDown" || summary.Message != "component A is down" {
+		t.Errorf("summary = %+v, want False with the Failed rule's reason and message", summary)
+	}
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Running", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	summary = meta.FindStatusCondition(obj.Status.Conditions, "PhaseSummary")
+	if summary.Status != metav1.ConditionTrue || summary.Reason != "Ready" || summary.Message != "Phase is Ready" {
+		t.Errorf("summary = %+v, want True/Ready for a rule without a summary", summary)
+	}
+}
+
+func TestWithSummaryCondition_NotReadByRules(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	green := []rules.PhaseRule{rules.NewPhaseRule("Ready", rules.AllPresentEqual(metav1.ConditionTrue))}
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, green, WithSummaryCondition("Available", "Ready"))
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionFalse, "Down", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if !meta.IsStatusConditionFalse(obj.Status.Conditions, "Available") {
+		t.Fatalf("conditions = %v, want Available False", obj.Status.Conditions)
+	}
+
+	// the False summary condition must not hold the phase back
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Up", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Ready" || !meta.IsStatusConditionTrue(obj.Status.Conditions, "Available") {
+		t.Errorf("phase = %q, conditions = %v, want Ready and Available True", obj.Status.Phase, obj.Status.Conditions)
+	}
+}
+
+func TestSetConditions_LogsMatchedRule(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 1})
+	ctx := log.IntoContext(context.Background(), logger)
+
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules)
+
+	if err := m.SetConditions(ctx, []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Up"},
+		{Type: "B", Status: metav1.ConditionFalse, Reason: "Down"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	if want := `"level"=1 "msg"="phase rule matched" "phase"="Failed" "index"=1 "priority"=0`; !slices.Contains(lines, want) {
+		t.Errorf("log lines = %q, want %s", lines, want)
+	}
+
+	logged := len(lines)
+
+	if err := m.SetCondition(ctx, "B", metav1.ConditionUnknown, "Probing", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	var ruleLines []string
+	for _, line := range lines[logged:] {
+		if strings.Contains(line, "phase rule") {
+			ruleLines = append(ruleLines, line)
+		}
+	}
+
+	want := `"level"=0 "msg"="no phase rule matched, falling back to the default phase" "phase"="Unknown" "diagnostics"=[{"Phase"="Ready" "Satisfied"=false "Unmet"=["B"]} {"Phase"="Failed" "Satisfied"=false "Unmet"=["A"]}]`
+	if len(ruleLines) != 1 || ruleLines[0] != want {
+		t.Errorf("log lines = %q, want the single line %s", ruleLines, want)
+	}
+}
+
+func TestWithOnlyPatchOnStatusChange(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules, WithOnlyPatchOnStatusChange())
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionFalse, "Retrying", "attempt 1"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	phase, changed, err := m.SetConditionAndReport(ctx, "A", metav1.ConditionFalse, "Retrying", "attempt 2")
+	if err != nil || changed || phase != "Failed" {
+		t.Fatalf("SetConditionAndReport() = %q, %v, %v, want Failed and no change", phase, changed, err)
+	}
+	if c := meta.FindStatusCondition(obj.Status.Conditions, "A"); c.Message != "attempt 1" {
+		t.Errorf("message = %q, want the previous one kept", c.Message)
+	}
+	if len(statusClient.patches) != 1 {
+		t.Errorf("got %d patches, want none for a message change", len(statusClient.patches)-1)
+	}
+
+	if err := m.SetConditions(ctx, []Condition{{Type: "A", Status: metav1.ConditionTrue, Reason: "Up"}}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+	if len(statusClient.patches) != 2 {
+		t.Errorf("got %d patches, want one for the status transition", len(statusClient.patches)-1)
+	}
+
+	// a new generation is observed even if the status stays
+	obj.Generation = 2
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Up", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if len(statusClient.patches) != 3 || obj.Status.ObservedGeneration != 2 {
+		t.Errorf("got %d patches, observed generation %d, want a patch observing generation 2", len(statusClient.patches), obj.Status.ObservedGeneration)
+	}
+}
+
+func TestForcePhase_BypassesRules(t *testing.T) {
+	obj := newTestObject(2)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules)
+
+	if err := m.ForcePhase(context.Background(), "Maintenance"); err != nil {
+		t.Fatalf("ForcePhase() error = %v", err)
+	}
+
+	if obj.Status.Phase != "Maintenance" {
+		t.Errorf("Phase = %q, want %q", obj.Status.Phase, "Maintenance")
+	}
+	if obj.Status.ObservedGeneration != 2 {
+		t.Errorf("ObservedGeneration = %d, want 2", obj.Status.ObservedGeneration)
+	}
+	if len(statusClient.patches) != 1 {
+		t.Fatalf("got %d patches, want 1", len(statusClient.patches))
+	}
+	if want := `{"status":{"observedGeneration":2,"phase":"Maintenance"}}`; string(statusClient.patches[0]) != want {
+		t.Errorf("patch = %s, want %s", statusClient.patches[0], want)
+	}
+}
+
+func TestForcePhase_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules)
+
+	for range 2 {
+		if err := m.ForcePhase(ctx, "Maintenance"); err != nil {
+			t.Fatalf("ForcePhase() error = %v", err)
+		}
+	}
+
+	if len(statusClient.patches) != 1 {
+		t.Errorf("got %d patches, want 1, forcing the phase the object has is a no-op", len(statusClient.patches))
+	}
+}
+
+func TestRecomputePhase(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(2)
+	obj.Status.Phase = "Ready"
+	obj.Status.Conditions = []metav1.Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Up"},
+		{Type: "B", Status: metav1.ConditionFalse, Reason: "Down"},
+	}
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules)
+
+	phase, err := m.RecomputePhase(ctx)
+	if err != nil {
+		t.Fatalf("RecomputePhase() error = %v", err)
+	}
+	if phase != "Failed" || obj.Status.Phase != "Failed" {
+		t.Errorf("RecomputePhase() = %q, object phase %q, want Failed", phase, obj.Status.Phase)
+	}
+	if obj.Status.ObservedGeneration != 2 {
+		t.Errorf("ObservedGeneration = %d, want 2", obj.Status.ObservedGeneration)
+	}
+	if want := `{"status":{"observedGeneration":2,"phase":"Failed"}}`; len(statusClient.patches) != 1 || string(statusClient.patches[0]) != want {
+		t.Fatalf("patches = %q, want one patch %s", statusClient.patches, want)
+	}
+
+	obj.Generation = 3
+
+	if phase, err = m.RecomputePhase(ctx); err != nil || phase != "Failed" {
+		t.Fatalf("RecomputePhase() = %q, %v, want Failed", phase, err)
+	}
+	if len(statusClient.patches) != 1 {
+		t.Errorf("got %d patches, want no patch when the phase is unchanged", len(statusClient.patches))
+	}
+	if obj.Status.ObservedGeneration != 2 {
+		t.Errorf("ObservedGeneration = %d, want it untouched", obj.Status.ObservedGeneration)
+	}
+}
+
+func TestNewManager_NilConditions(t *testing.T) {
+	ctx := context.Background()
+
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, nil, obj, testRules)
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Up", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if !meta.IsStatusConditionTrue(obj.Status.Conditions, "A") {
+		t.Errorf("conditions = %v, want A True on the object's conditions field", obj.Status.Conditions)
+	}
+
+	bare := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	m = NewManagerForConditions(&fakeStatusClient{}, nil, bare, testRules)
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Up", ""); !errors.Is(err, ErrNoConditions) {
+		t.Errorf("SetCondition() error = %v, want ErrNoConditions", err)
+	}
+	if _, err := m.RecomputePhase(ctx); !errors.Is(err, ErrNoConditions) {
+		t.Errorf("RecomputePhase() error = %v, want ErrNoConditions", err)
+	}
+	if phase, _ := m.ComputeOnly(ctx, []Condition{{Type: "A", Status: metav1.ConditionFalse}}); phase != "" {
+		t.Errorf("ComputeOnly() phase = %q, want none stored without accessors", phase)
+	}
+}
+
+func TestConditions_ReturnsCopy(t *testing.T) {
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules)
+
+	if got := m.Conditions(); got != nil {
+		t.Errorf("Conditions() = %v, want nil before any condition is set", got)
+	}
+
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionTrue, "Done", "a is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	snapshot := m.Conditions()
+	if len(snapshot) != 1 || snapshot[0].Type != "A" {
+		t.Fatalf("Conditions() = %v, want only A", snapshot)
+	}
+
+	snapshot[0].Status = metav1.ConditionFalse
+	snapshot = append(snapshot, metav1.Condition{Type: "B"})
+
+	if !meta.IsStatusConditionTrue(obj.Status.Conditions, "A") {
+		t.Error("mutating the snapshot changed the manager's condition A")
+	}
+	if len(obj.Status.Conditions) != 1 {
+		t.Errorf("mutating the snapshot changed the manager's conditions to %v", obj.Status.Conditions)
+	}
+}
+
+func TestGetCondition(t *testing.T) {
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules)
+
+	if got := m.GetCondition("A"); got != nil {
+		t.Errorf("GetCondition() = %v, want nil before A is set", got)
+	}
+	if m.HasCondition("A", metav1.ConditionTrue) {
+		t.Error("HasCondition() = true, want false before A is set")
+	}
+
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionTrue, "Done", "a is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	condition := m.GetCondition("A")
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != "Done" {
+		t.Fatalf("GetCondition() = %v, want A True/Done", condition)
+	}
+	if !m.HasCondition("A", metav1.ConditionTrue) || m.HasCondition("A", metav1.ConditionFalse) {
+		t.Error("HasCondition() disagrees with A being True")
+	}
+
+	condition.Status = metav1.ConditionFalse
+	if !meta.IsStatusConditionTrue(obj.Status.Conditions, "A") {
+		t.Error("mutating the returned condition changed the manager's condition A")
+	}
+}
+
+func TestRemoveCondition(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules, WithDefaultPhase("Pending"))
+
+	if err := m.SetConditions(ctx, []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Done"},
+		{Type: "B", Status: metav1.ConditionFalse, Reason: "Broken"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+	if obj.Status.Phase != "Failed" {
+		t.Fatalf("Phase = %q, want %q", obj.Status.Phase, "Failed")
+	}
+
+	obj.Generation = 2
+
+	// B made the Failed rule satisfiable, without it no rule is
+	if err := m.RemoveCondition(ctx, "B"); err != nil {
+		t.Fatalf("RemoveCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Pending" {
+		t.Errorf("Phase = %q, want the default %q", obj.Status.Phase, "Pending")
+	}
+	if meta.FindStatusCondition(obj.Status.Conditions, "B") != nil {
+		t.Error("condition B is still present")
+	}
+	if obj.Status.ObservedGeneration != 2 {
+		t.Errorf("ObservedGeneration = %d, want 2", obj.Status.ObservedGeneration)
+	}
+	if len(statusClient.patches) != 2 {
+		t.Errorf("got %d patches, want 2", len(statusClient.patches))
+	}
+
+	if err := m.RemoveCondition(ctx, "B"); err != nil {
+		t.Fatalf("RemoveCondition() error = %v", err)
+	}
+	if len(statusClient.patches) != 2 {
+		t.Errorf("got %d patches, want none for an absent condition", len(statusClient.patches))
+	}
+}
+
+func TestWithUnknownGracePeriod(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules,
+		WithClock(clock), WithUnknownGracePeriod(time.Minute))
+
+	if err := m.SetConditions(ctx, []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Done", Message: "a is done"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done", Message: "b is done"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Fatalf("Phase = %q, want %q", obj.Status.Phase, "Ready")
+	}
+
+	// a brief blip is suppressed
+	clock.Step(10 * time.Second)
+	if err := m.SetCondition(ctx, "A", metav1.ConditionUnknown, "Unreachable", "api unreachable"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want %q kept within the grace period", obj.Status.Phase, "Ready")
+	}
+
+	clock.Step(20 * time.Second)
+	if err := m.SetCondition(ctx, "A", metav1.ConditionUnknown, "Unreachable", "api still unreachable"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want %q kept within the grace period", obj.Status.Phase, "Ready")
+	}
+
+	// a sustained Unknown is committed
+	clock.Step(time.Minute)
+	if err := m.SetCondition(ctx, "A", metav1.ConditionUnknown, "Unreachable", "api unreachable for a while"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != rules.PhaseUnknown {
+		t.Errorf("Phase = %q, want %q after the grace period", obj.Status.Phase, rules.PhaseUnknown)
+	}
+}
+
+func TestWithUnknownGracePeriod_IdenticalUpdates(t *testing.T) {
+	ctx := context.Background()
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules,
+		WithClock(clock), WithUnknownGracePeriod(time.Minute))
+
+	if err := m.SetConditions(ctx, []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Done", Message: "a is done"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done", Message: "b is done"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	// the controller sets the same Unknown condition on every reconcile
+	for _, step := range []time.Duration{10 * time.Second, 20 * time.Second} {
+		clock.Step(step)
+		if err := m.SetCondition(ctx, "A", metav1.ConditionUnknown, "Unreachable", "api unreachable"); err != nil {
+			t.Fatalf("SetCondition() error = %v", err)
+		}
+		if obj.Status.Phase != "Ready" {
+			t.Errorf("Phase = %q, want %q kept within the grace period", obj.Status.Phase, "Ready")
+		}
+	}
+
+	clock.Step(time.Minute)
+	phase, changed, err := m.SetConditionAndReport(ctx, "A", metav1.ConditionUnknown, "Unreachable", "api unreachable")
+	if err != nil {
+		t.Fatalf("SetConditionAndReport() error = %v", err)
+	}
+	if phase != rules.PhaseUnknown || obj.Status.Phase != rules.PhaseUnknown {
+		t.Errorf("phase = %q, Phase = %q, want %q after the grace period", phase, obj.Status.Phase, rules.PhaseUnknown)
+	}
+	if changed {
+		t.Error("changed = true, want false for an unchanged condition")
+	}
+}
+
+func TestWithUnknownGracePeriod_NoPreviousPhase(t *testing.T) {
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules,
+		WithClock(&fakeClock{now: time.Now()}), WithUnknownGracePeriod(time.Hour))
+
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionUnknown, "Unreachable", "api unreachable"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != rules.PhaseUnknown {
+		t.Errorf("Phase = %q, want %q without a known phase to keep", obj.Status.Phase, rules.PhaseUnknown)
+	}
+}
+
+func TestWithDefaultPhase(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules,
+		WithSummaryCondition("Available", "Ready"), WithDefaultPhase("Pending"))
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Done", "a is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Pending" {
+		t.Errorf("Phase = %q, want the default %q while B is missing", obj.Status.Phase, "Pending")
+	}
+	if summary := meta.FindStatusCondition(obj.Status.Conditions, "Available"); summary == nil || summary.Reason != "Pending" {
+		t.Errorf("summary = %v, want reason %q", summary, "Pending")
+	}
+
+	if err := m.SetCondition(ctx, "B", metav1.ConditionTrue, "Done", "b is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want %q", obj.Status.Phase, "Ready")
+	}
+}
+
+func TestWithSortedConditions(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules,
+		WithSummaryCondition("Available", "Ready"), WithSortedConditions())
+
+	if err := m.SetCondition(ctx, "B", metav1.ConditionTrue, "Done", "b is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Done", "a is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	var patched testObject
+	if err := json.Unmarshal(statusClient.patches[len(statusClient.patches)-1], &patched); err != nil {
+		t.Fatalf("unmarshal patch: %v", err)
+	}
+
+	want := []string{"A", "Available", "B"}
+	if len(patched.Status.Conditions) != len(want) {
+		t.Fatalf("patched %d conditions, want %d", len(patched.Status.Conditions), len(want))
+	}
+	for i, condition := range patched.Status.Conditions {
+		if condition.Type != want[i] {
+			t.Errorf("patched condition %d = %q, want %q", i, condition.Type, want[i])
+		}
+	}
+}
+
+// statefulObject keeps its phase under status.state and doesn't implement Object2.
+type statefulObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status struct {
+		State      string             `json:"state,omitempty"`
+		Conditions []metav1.Condition `json:"conditions,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+func (o *statefulObject) DeepCopyObject() runtime.Object {
+	out := *o
+	o.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Status.Conditions = slices.Clone(o.Status.Conditions)
+	return &out
+}
+
+func TestNewManagerForConditions_WithPhaseSetter(t *testing.T) {
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+
+	var phase string
+	m := NewManagerForConditions(statusClient, &obj.Status.Conditions, obj, testRules, WithPhaseSetter(func(p string) { phase = p }))
+
+	if err := m.SetConditions(context.Background(), []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Up"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Up"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	if phase != "Ready" {
+		t.Errorf("phase setter got %q, want Ready", phase)
+	}
+	if obj.Status.Phase != "" {
+		t.Errorf("object phase = %q, want it left alone", obj.Status.Phase)
+	}
+	if obj.Status.ObservedGeneration != 1 || len(statusClient.patches) != 1 {
+		t.Errorf("got observed generation %d and %d patches, want 1 and 1", obj.Status.ObservedGeneration, len(statusClient.patches))
+	}
+}
+
+func TestNewManagerForObject_WithPhaseAccessors(t *testing.T) {
+	obj := &statefulObject{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 1}}
+	statusClient := &fakeStatusClient{}
+	m := NewManagerForObject(statusClient, &obj.Status.Conditions, obj, testRules, WithPhaseAccessors(
+		func(o client.Object) string { return o.(*statefulObject).Status.State },
+		func(o client.Object, phase string) { o.(*statefulObject).Status.State = phase },
+	))
+
+	if err := m.SetConditions(context.Background(), []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Done", Message: "a is done"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done", Message: "b is done"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	if obj.Status.State != "Ready" {
+		t.Errorf("State = %q, want %q", obj.Status.State, "Ready")
+	}
+
+	if len(statusClient.patches) != 1 || !strings.Contains(string(statusClient.patches[0]), `"state":"Ready"`) {
+		t.Errorf("patches = %s, want the state patched", statusClient.patches)
+	}
+}
+
+func TestWithBeforePatch(t *testing.T) {
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+
+	var hookPhase string
+
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules, WithBeforePatch(func(o client.Object) {
+		hookPhase = o.(*testObject).Status.Phase
+		o.SetLabels(map[string]string{"example.com/phase": hookPhase})
+	}))
+
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionFalse, "Broken", "a broke"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	if hookPhase != "Failed" {
+		t.Errorf("hook saw phase %q, want the computed %q", hookPhase, "Failed")
+	}
+
+	if len(statusClient.patches) != 1 {
+		t.Fatalf("got %d patches, want 1", len(statusClient.patches))
+	}
+
+	var patched testObject
+	if err := json.Unmarshal(statusClient.patches[0], &patched); err != nil {
+		t.Fatalf("unmarshal patch: %v", err)
+	}
+
+	if got := patched.Labels["example.com/phase"]; got != "Failed" {
+		t.Errorf("patched label = %q, want %q in %s", got, "Failed", statusClient.patches[0])
+	}
+}
+
+func TestComputeOnly(t *testing.T) {
+	obj := newTestObject(1)
+	m := NewManager(nil, &obj.Status.Conditions, obj, testRules)
+
+	phase, conditions := m.ComputeOnly(context.Background(), []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Up"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Up"},
+	})
+
+	if phase != "Ready" {
+		t.Errorf("ComputeOnly() phase = %q, want Ready", phase)
+	}
+	if len(conditions) != 2 || !meta.IsStatusConditionTrue(conditions, "A") || !meta.IsStatusConditionTrue(conditions, "B") {
+		t.Errorf("ComputeOnly() conditions = %v, want A and B True", conditions)
+	}
+
+	if obj.Status.Phase != "" || len(obj.Status.Conditions) != 0 || obj.Status.ObservedGeneration != 0 {
+		t.Errorf("ComputeOnly() changed the object: %+v", obj.Status)
+	}
+}
+
+func TestPreviewPatch(t *testing.T) {
+	obj := newTestObject(2)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules,
+		WithClock(&fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}))
+
+	preview, err := m.PreviewPatch(context.Background(), []Condition{
+		{Type: "A", Status: metav1.ConditionFalse, Reason: "Broken", Message: "a broke"},
+	})
+	if err != nil {
+		t.Fatalf("PreviewPatch() error = %v", err)
+	}
+
+	var patched testObject
+	if err := json.Unmarshal(preview, &patched); err != nil {
+		t.Fatalf("unmarshal preview: %v", err)
+	}
+
+	if patched.Status.Phase != "Failed" {
+		t.Errorf("previewed phase = %q, want %q", patched.Status.Phase, "Failed")
+	}
+	if c := meta.FindStatusCondition(patched.Status.Conditions, "A"); c == nil || c.Status != metav1.ConditionFalse || c.Reason != "Broken" {
+		t.Errorf("previewed conditions = %+v, want A False with reason Broken", patched.Status.Conditions)
+	}
+
+	if len(statusClient.patches) != 0 {
+		t.Errorf("got %d patches, want none sent", len(statusClient.patches))
+	}
+	if obj.Status.Phase != "" || obj.Status.ObservedGeneration != 0 || len(obj.Status.Conditions) != 0 {
+		t.Errorf("object status = %+v, want it untouched", obj.Status)
+	}
+
+	// the preview left nothing behind, the real call sends the same patch
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionFalse, "Broken", "a broke"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if len(statusClient.patches) != 1 || string(statusClient.patches[0]) != string(preview) {
+		t.Errorf("patch = %s, want the preview %s", statusClient.patches, preview)
+	}
+}
+
+func TestSetConditionAndReport(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules)
+
+	phase, changed, err := m.SetConditionAndReport(ctx, "A", metav1.ConditionTrue, "Up", "")
+	if err != nil || !changed || phase != rules.PhaseUnknown {
+		t.Errorf("SetConditionAndReport(A) = %q, %v, %v, want Unknown, true, nil", phase, changed, err)
+	}
+
+	phase, changed, err = m.SetConditionAndReport(ctx, "B", metav1.ConditionTrue, "Up", "")
+	if err != nil || !changed || phase != "Ready" {
+		t.Errorf("SetConditionAndReport(B) = %q, %v, %v, want Ready, true, nil", phase, changed, err)
+	}
+
+	phase, changed, err = m.SetConditionAndReport(ctx, "B", metav1.ConditionTrue, "Up", "")
+	if err != nil || changed || phase != "Ready" {
+		t.Errorf("SetConditionAndReport(B) again = %q, %v, %v, want Ready, false, nil", phase, changed, err)
+	}
+
+	if len(statusClient.patches) != 2 {
+		t.Errorf("got %d patches, want 2", len(statusClient.patches))
+	}
+}
+
+func TestSetConditions_IrrelevantConditionKeepsPhase(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules)
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionFalse, "Broken", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if err := m.ForcePhase(ctx, "Maintenance"); err != nil {
+		t.Fatalf("ForcePhase() error = %v", err)
+	}
+
+	// no rule reads Progressing, the phase isn't recomputed but the condition is still patched
+	if err := m.SetCondition(ctx, "Progressing", metav1.ConditionTrue, "Rolling", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Maintenance" {
+		t.Errorf("phase = %q, want Maintenance kept", obj.Status.Phase)
+	}
+	if len(statusClient.patches) != 3 || !meta.IsStatusConditionTrue(obj.Status.Conditions, "Progressing") {
+		t.Errorf("got %d patches and conditions %v, want Progressing patched", len(statusClient.patches), obj.Status.Conditions)
+	}
+
+	if err := m.SetCondition(ctx, "B", metav1.ConditionFalse, "Broken", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Failed" {
+		t.Errorf("phase = %q, want Failed recomputed after a condition the rules read", obj.Status.Phase)
+	}
+}
+
+func TestSetCondition_Concurrent(t *testing.T) {
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules, WithSummaryCondition("Available", "Ready"))
+
+	var wg sync.WaitGroup
+
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			conditionType := fmt.Sprintf("C%d", i)
+			if err := m.SetCondition(context.Background(), conditionType, metav1.ConditionTrue, "Done", ""); err != nil {
+				t.Errorf("SetCondition(%s) error = %v", conditionType, err)
+			}
+			_ = m.Conditions()
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		if err := m.SetConditions(context.Background(), []Condition{
+			{Type: "A", Status: metav1.ConditionTrue, Reason: "Done"},
+			{Type: "B", Status: metav1.ConditionTrue, Reason: "Done"},
+		}); err != nil {
+			t.Errorf("SetConditions() error = %v", err)
+		}
+	}()
+
+	wg.Wait()
+
+	// 20 conditions, A, B and the summary
+	if got := len(m.Conditions()); got != 23 {
+		t.Errorf("got %d conditions, want 23", got)
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want %q", obj.Status.Phase, "Ready")
+	}
+	if len(statusClient.patches) != 21 {
+		t.Errorf("got %d patches, want 21", len(statusClient.patches))
+	}
+}
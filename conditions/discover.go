@@ -0,0 +1,117 @@
+package conditions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// ConditionTypesTag is the struct tag declaring the condition types a []metav1.Condition field holds,
+// as a comma separated list, e.g.
+//
+//	Conditions []metav1.Condition `json:"conditions,omitempty" conditionTypes:"BucketExists,Ready"`
+const ConditionTypesTag = "conditionTypes"
+
+var conditionsType = reflect.TypeOf([]metav1.Condition(nil))
+
+// DiscoverConditionTypes returns the condition types declared with ConditionTypesTag on the []metav1.Condition
+// fields of obj, searching nested structs such as Status. Compare the result with the ConditionTypes() of the
+// phase rules to catch rules and CRD drifting apart.
+// obj must be a struct or a pointer to one, with at least one tagged field.
+func DiscoverConditionTypes(obj any) (sets.Set[string], error) {
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("discover condition types: %T is not a struct", obj)
+	}
+
+	types := sets.New[string]()
+
+	if !discoverConditionTypes(t, types, sets.New[reflect.Type]()) {
+		return nil, fmt.Errorf("discover condition types: %s has no []metav1.Condition field tagged %q", t, ConditionTypesTag)
+	}
+
+	return types, nil
+}
+
+// discoverConditionTypes adds the types declared on the fields of t, reporting whether a tagged field was found.
+func discoverConditionTypes(t reflect.Type, types sets.Set[string], visited sets.Set[reflect.Type]) bool {
+	if visited.Has(t) {
+		return false
+	}
+
+	visited.Insert(t)
+
+	found := false
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		if field.Type == conditionsType {
+			declared, ok := field.Tag.Lookup(ConditionTypesTag)
+			if !ok {
+				continue
+			}
+
+			found = true
+
+			for conditionType := range strings.SplitSeq(declared, ",") {
+				if conditionType = strings.TrimSpace(conditionType); conditionType != "" {
+					types.Insert(conditionType)
+				}
+			}
+
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && discoverConditionTypes(fieldType, types, visited) {
+			found = true
+		}
+	}
+
+	return found
+}
+
+// findConditions returns a pointer to the first exported []metav1.Condition field of obj, searching nested structs
+// such as Status, nil if obj isn't a pointer to a struct or has no such field.
+func findConditions(obj any) *[]metav1.Condition {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	return findConditionsField(v.Elem())
+}
+
+func findConditionsField(v reflect.Value) *[]metav1.Condition {
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Type == conditionsType {
+			return v.Field(i).Addr().Interface().(*[]metav1.Condition)
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			if conditions := findConditionsField(v.Field(i)); conditions != nil {
+				return conditions
+			}
+		}
+	}
+
+	return nil
+}
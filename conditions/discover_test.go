@@ -0,0 +1,57 @@
+package conditions
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+const (
+	storeConditionBucketExists = "BucketExists"
+	storeConditionReady        = "Ready"
+)
+
+type storeStatus struct {
+	Phase      string             `json:"phase,omitempty"`
+	Conditions []metav1.Condition `json:"conditions,omitempty" conditionTypes:"BucketExists, Ready"`
+}
+
+type store struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status storeStatus `json:"status,omitempty"`
+}
+
+func TestDiscoverConditionTypes(t *testing.T) {
+	types, err := DiscoverConditionTypes(&store{})
+	if err != nil {
+		t.Fatalf("DiscoverConditionTypes() error = %v", err)
+	}
+
+	want := sets.New(storeConditionBucketExists, storeConditionReady)
+	if types.Len() != want.Len() {
+		t.Fatalf("DiscoverConditionTypes() = %v, want %v", types, want)
+	}
+	for conditionType := range want {
+		if !types.Has(conditionType) {
+			t.Errorf("DiscoverConditionTypes() = %v, missing %q", types, conditionType)
+		}
+	}
+}
+
+func TestDiscoverConditionTypes_Untagged(t *testing.T) {
+	if _, err := DiscoverConditionTypes(newTestObject(1)); err == nil {
+		t.Error("expected an error for an object without tagged conditions")
+	}
+}
+
+func TestDiscoverConditionTypes_NotAStruct(t *testing.T) {
+	for _, obj := range []any{nil, "store", []metav1.Condition{}} {
+		if _, err := DiscoverConditionTypes(obj); err == nil {
+			t.Errorf("expected an error for %T", obj)
+		}
+	}
+}
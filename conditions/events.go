@@ -0,0 +1,47 @@
+package conditions
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EventReasonPhaseChanged is the reason of the events recorded on phase transitions.
+	EventReasonPhaseChanged = "PhaseChanged"
+
+	// EventReasonPhaseForced is the reason of the events recorded on phase transitions made by ForcePhase.
+	EventReasonPhaseForced = "PhaseForced"
+)
+
+// WithEventRecorder records a Normal PhaseChanged event on the object whenever a status patch changes its phase,
+// so kubectl describe shows the phase history, or a PhaseForced event for ForcePhase. Patches leaving the phase as it
+// was record nothing.
+func WithEventRecorder(recorder record.EventRecorder) Option {
+	return func(m *ConditionsManager) {
+		m.recorder = recorder
+	}
+}
+
+// recordPhaseChange records an event if the phase of the object differs from that of base, the object before the patch.
+func (m *ConditionsManager) recordPhaseChange(base client.Object) {
+	if m.recorder == nil {
+		return
+	}
+
+	previous, phase := m.getPhase(base), m.getPhase(m.object)
+	if previous == phase {
+		return
+	}
+
+	switch {
+	case m.forcing && previous == "":
+		m.recorder.Eventf(m.object, corev1.EventTypeNormal, EventReasonPhaseForced, "Phase forced to %s", phase)
+	case m.forcing:
+		m.recorder.Eventf(m.object, corev1.EventTypeNormal, EventReasonPhaseForced, "Phase forced from %s to %s", previous, phase)
+	case previous == "":
+		m.recorder.Eventf(m.object, corev1.EventTypeNormal, EventReasonPhaseChanged, "Phase set to %s", phase)
+	default:
+		m.recorder.Eventf(m.object, corev1.EventTypeNormal, EventReasonPhaseChanged, "Phase changed from %s to %s", previous, phase)
+	}
+}
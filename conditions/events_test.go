@@ -0,0 +1,132 @@
+package conditions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestWithEventRecorder(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	recorder := record.NewFakeRecorder(10)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithEventRecorder(recorder))
+
+	steps := []struct {
+		conditionType string
+		status        metav1.ConditionStatus
+		want          string
+	}{
+		{"A", metav1.ConditionFalse, "Normal PhaseChanged Phase set to Failed"},
+		// still Failed, only a condition changed
+		{"B", metav1.ConditionTrue, ""},
+		{"A", metav1.ConditionTrue, "Normal PhaseChanged Phase changed from Failed to Ready"},
+	}
+
+	for _, step := range steps {
+		if err := m.SetCondition(ctx, step.conditionType, step.status, "Set", ""); err != nil {
+			t.Fatalf("SetCondition() error = %v", err)
+		}
+
+		select {
+		case event := <-recorder.Events:
+			if event != step.want {
+				t.Errorf("event = %q, want %q", event, step.want)
+			}
+		default:
+			if step.want != "" {
+				t.Errorf("no event, want %q", step.want)
+			}
+		}
+	}
+}
+
+func TestWithEventRecorder_ForcePhase(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	recorder := record.NewFakeRecorder(10)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithEventRecorder(recorder))
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionFalse, "Broken", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	<-recorder.Events
+
+	if err := m.ForcePhase(ctx, "Maintenance"); err != nil {
+		t.Fatalf("ForcePhase() error = %v", err)
+	}
+
+	if want := "Normal PhaseForced Phase forced from Failed to Maintenance"; len(recorder.Events) != 1 || <-recorder.Events != want {
+		t.Errorf("want the single event %q", want)
+	}
+}
+
+func TestWithEventRecorder_NotOnPreview(t *testing.T) {
+	obj := newTestObject(1)
+	recorder := record.NewFakeRecorder(10)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithEventRecorder(recorder))
+
+	if _, err := m.PreviewPatch(context.Background(), []Condition{{Type: "A", Status: metav1.ConditionFalse, Reason: "Broken"}}); err != nil {
+		t.Fatalf("PreviewPatch() error = %v", err)
+	}
+
+	if len(recorder.Events) != 0 {
+		t.Errorf("got event %q for a preview", <-recorder.Events)
+	}
+}
+
+func TestWithOnPhaseChange(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+
+	type change struct {
+		previous, phase string
+		forced          bool
+	}
+	var changes []change
+
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithOnPhaseChange(func(_ context.Context, previous, phase string, forced bool) error {
+		changes = append(changes, change{previous, phase, forced})
+		return nil
+	}))
+
+	for _, condition := range []Condition{
+		{Type: "A", Status: metav1.ConditionFalse, Reason: "Broken"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done"},
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Done"},
+	} {
+		if err := m.SetConditions(ctx, []Condition{condition}); err != nil {
+			t.Fatalf("SetConditions() error = %v", err)
+		}
+	}
+
+	if err := m.ForcePhase(ctx, "Maintenance"); err != nil {
+		t.Fatalf("ForcePhase() error = %v", err)
+	}
+
+	want := []change{{"", "Failed", false}, {"Failed", "Ready", false}, {"Ready", "Maintenance", true}}
+	if !slices.Equal(changes, want) {
+		t.Errorf("phase changes = %v, want %v", changes, want)
+	}
+}
+
+func TestWithOnPhaseChange_Error(t *testing.T) {
+	obj := newTestObject(1)
+	statusClient := &fakeStatusClient{}
+	errExternal := errors.New("external system down")
+
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules, WithOnPhaseChange(func(context.Context, string, string, bool) error {
+		return errExternal
+	}))
+
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionFalse, "Broken", ""); !errors.Is(err, errExternal) {
+		t.Errorf("SetCondition() error = %v, want %v", err, errExternal)
+	}
+	if len(statusClient.patches) != 1 {
+		t.Errorf("got %d patches, want the patch made before the callback", len(statusClient.patches))
+	}
+}
@@ -0,0 +1,51 @@
+package conditions
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FilterFresh returns the conditions observed at generation or later, leaving out the stale ones that were
+// set for an older spec. The input isn't modified.
+func FilterFresh(conditions []metav1.Condition, generation int64) []metav1.Condition {
+	var fresh []metav1.Condition
+
+	for _, condition := range conditions {
+		if condition.ObservedGeneration >= generation {
+			fresh = append(fresh, condition)
+		}
+	}
+
+	return fresh
+}
+
+// WithFreshConditionsOnly evaluates the phase rules against the conditions observed at the object's current
+// generation only, so a phase isn't computed off conditions predating the latest spec change. Stale conditions
+// are left in the status, to the rules they are missing, i.e. Unknown.
+func WithFreshConditionsOnly() Option {
+	return func(m *ConditionsManager) {
+		m.freshConditionsOnly = true
+	}
+}
+
+// ruleConditions returns the conditions the phase rules are evaluated against. The summary condition follows the
+// phase, it is left out so matchers reading every present condition, like AllPresentEqual, don't read it back.
+func (m *ConditionsManager) ruleConditions() *[]metav1.Condition {
+	if m.conditions == nil || (!m.freshConditionsOnly && m.summaryConditionType == "") {
+		return m.conditions
+	}
+
+	conditions := *m.conditions
+	if m.freshConditionsOnly {
+		conditions = FilterFresh(conditions, m.object.GetGeneration())
+	}
+
+	if m.summaryConditionType != "" {
+		conditions = slices.DeleteFunc(slices.Clone(conditions), func(condition metav1.Condition) bool {
+			return condition.Type == m.summaryConditionType
+		})
+	}
+
+	return &conditions
+}
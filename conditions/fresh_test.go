@@ -0,0 +1,81 @@
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/rules"
+)
+
+func TestFilterFresh(t *testing.T) {
+	conditions := []metav1.Condition{
+		{Type: "A", Status: metav1.ConditionTrue, ObservedGeneration: 1},
+		{Type: "B", Status: metav1.ConditionFalse, ObservedGeneration: 3},
+		{Type: "C", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+		{Type: "D", Status: metav1.ConditionUnknown, ObservedGeneration: 4},
+	}
+
+	tests := []struct {
+		name       string
+		generation int64
+		want       []string
+	}{
+		{"all fresh", 0, []string{"A", "B", "C", "D"}},
+		{"some stale", 3, []string{"B", "D"}},
+		{"observed at generation is fresh", 2, []string{"B", "C", "D"}},
+		{"all stale", 5, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fresh := FilterFresh(conditions, tt.generation)
+
+			var got []string
+			for _, condition := range fresh {
+				got = append(got, condition.Type)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("FilterFresh() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("FilterFresh() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	if len(conditions) != 4 || conditions[0].Type != "A" {
+		t.Errorf("FilterFresh() modified its input: %v", conditions)
+	}
+}
+
+func TestWithFreshConditionsOnly(t *testing.T) {
+	obj := newTestObject(2)
+	obj.Status.Conditions = []metav1.Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Done", ObservedGeneration: 1},
+	}
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithFreshConditionsOnly())
+
+	if err := m.SetCondition(context.Background(), "B", metav1.ConditionTrue, "Done", "b is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	// A predates generation 2, to the rules it is missing
+	if obj.Status.Phase != rules.PhaseUnknown {
+		t.Errorf("Phase = %q, want %q while A is stale", obj.Status.Phase, rules.PhaseUnknown)
+	}
+	if len(obj.Status.Conditions) != 2 {
+		t.Errorf("got %d conditions, want the stale one kept", len(obj.Status.Conditions))
+	}
+
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionTrue, "Redone", "a is done again"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want %q once A is observed at generation 2", obj.Status.Phase, "Ready")
+	}
+}
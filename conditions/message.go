@@ -0,0 +1,82 @@
+package conditions
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/rules"
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// MessageComposer builds the summary condition's message for phase from the conditions that determined it:
+// the conditions the satisfied rule refers to, or every condition when no rule was satisfied, the phase was
+// forced, or the rule refers to no condition types. The summary condition itself is never passed.
+type MessageComposer func(phase string, conditions []metav1.Condition) string
+
+// WithMessageComposer composes the summary condition's message with compose instead of "Phase is <phase>".
+// It only has an effect together with WithSummaryCondition.
+func WithMessageComposer(compose MessageComposer) Option {
+	return func(m *ConditionsManager) {
+		m.composeMessage = compose
+	}
+}
+
+// ComposeReasons is a MessageComposer listing the conditions that aren't True with their reason and message,
+// e.g. "Phase is Failed: A is False (Broken: disk full); B is Unknown (Pending)".
+func ComposeReasons(phase string, conditions []metav1.Condition) string {
+	message := "Phase is " + phase
+
+	var reasons []string
+
+	for _, condition := range conditions {
+		if condition.Status == metav1.ConditionTrue {
+			continue
+		}
+
+		reason := fmt.Sprintf("%s is %s", condition.Type, condition.Status)
+
+		switch {
+		case condition.Reason != "" && condition.Message != "":
+			reason += fmt.Sprintf(" (%s: %s)", condition.Reason, condition.Message)
+		case condition.Reason != "":
+			reason += fmt.Sprintf(" (%s)", condition.Reason)
+		case condition.Message != "":
+			reason += fmt.Sprintf(" (%s)", condition.Message)
+		}
+
+		reasons = append(reasons, reason)
+	}
+
+	if len(reasons) == 0 {
+		return message
+	}
+
+	return message + ": " + strings.Join(reasons, "; ")
+}
+
+// phaseMessage returns the summary condition's message for phase computed by rule, nil if none did.
+func (m *ConditionsManager) phaseMessage(phase string, rule rules.PhaseRule) string {
+	if m.composeMessage == nil {
+		return "Phase is " + phase
+	}
+
+	var referenced sets.Set[string]
+
+	if rule != nil && rule.ConditionTypes().Len() > 0 {
+		referenced = rule.ConditionTypes()
+	}
+
+	var determining []metav1.Condition
+
+	for _, condition := range *m.conditions {
+		if condition.Type == m.summaryConditionType || (referenced != nil && !referenced.Has(condition.Type)) {
+			continue
+		}
+
+		determining = append(determining, condition)
+	}
+
+	return m.composeMessage(phase, determining)
+}
@@ -0,0 +1,59 @@
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/rules"
+)
+
+func TestWithMessageComposer(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	phaseRules := append([]rules.PhaseRule{
+		rules.NewPhaseRule("Degraded", rules.ConditionsAll(rules.ConditionEquals("C", metav1.ConditionFalse))),
+	}, testRules...)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, phaseRules,
+		WithSummaryCondition("Available", "Ready"), WithMessageComposer(ComposeReasons))
+
+	if err := m.SetConditions(ctx, []Condition{
+		{Type: "A", Status: metav1.ConditionFalse, Reason: "Broken", Message: "disk full"},
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done", Message: "b is done"},
+		{Type: "C", Status: metav1.ConditionUnknown, Reason: "Pending"},
+	}); err != nil {
+		t.Fatalf("SetConditions() error = %v", err)
+	}
+
+	summary := meta.FindStatusCondition(obj.Status.Conditions, "Available")
+	if summary == nil {
+		t.Fatal("summary condition not set")
+	}
+
+	// C is not referenced by the Failed rule, B is True
+	if want := "Phase is Failed: A is False (Broken: disk full)"; summary.Message != want {
+		t.Errorf("summary message = %q, want %q", summary.Message, want)
+	}
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Done", "a is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	summary = meta.FindStatusCondition(obj.Status.Conditions, "Available")
+	if want := "Phase is Ready"; summary.Message != want {
+		t.Errorf("summary message = %q, want %q", summary.Message, want)
+	}
+}
+
+func TestComposeReasons(t *testing.T) {
+	got := ComposeReasons(rules.PhaseUnknown, []metav1.Condition{
+		{Type: "A", Status: metav1.ConditionUnknown, Message: "waiting on the api"},
+		{Type: "B", Status: metav1.ConditionFalse},
+	})
+
+	if want := "Phase is Unknown: A is Unknown (waiting on the api); B is False"; got != want {
+		t.Errorf("ComposeReasons() = %q, want %q", got, want)
+	}
+}
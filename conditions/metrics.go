@@ -0,0 +1,78 @@
+package conditions
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const meterName = tracerName
+
+// Metrics recorded on phase transitions.
+const (
+	// MetricPhaseTransitions counts phase transitions by AttributeFromPhase and AttributeToPhase,
+	// phase_transitions_total once exported to Prometheus.
+	MetricPhaseTransitions = "phase_transitions"
+	// MetricPhase is 1 for the current phase of each object, by AttributeNamespace, AttributeName and
+	// AttributePhase, and 0 for the phase it left.
+	MetricPhase = "phase"
+)
+
+// Attributes of the metrics recorded on phase transitions, besides AttributePhase.
+const (
+	AttributeFromPhase = attribute.Key("from")
+	AttributeToPhase   = attribute.Key("to")
+	AttributeNamespace = attribute.Key("namespace")
+	AttributeName      = attribute.Key("name")
+)
+
+// WithMeterProvider makes the manager record MetricPhaseTransitions and MetricPhase whenever a status patch changes
+// the object's phase, e.g. for dashboards of the phase distribution. Patches leaving the phase as it was record
+// nothing. Only the OpenTelemetry metric API is used, pass the provider of whichever SDK the controller is set up
+// with, e.g. one exporting to Prometheus.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(m *ConditionsManager) {
+		m.meterProvider = provider
+	}
+}
+
+// recordPhaseMetrics records the phase transition if the phase of the object differs from that of base,
+// the object before the patch.
+func (m *ConditionsManager) recordPhaseMetrics(ctx context.Context, base client.Object) {
+	if m.meterProvider == nil {
+		return
+	}
+
+	previous, phase := m.getPhase(base), m.getPhase(m.object)
+	if previous == phase {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+	meter := m.meterProvider.Meter(meterName)
+
+	transitions, err := meter.Int64Counter(MetricPhaseTransitions, metric.WithDescription("Number of phase transitions."))
+	if err != nil {
+		logger.Error(err, "failed to create metric", "metric", MetricPhaseTransitions)
+		return
+	}
+
+	current, err := meter.Int64Gauge(MetricPhase, metric.WithDescription("The current phase of an object, 1 for the current phase."))
+	if err != nil {
+		logger.Error(err, "failed to create metric", "metric", MetricPhase)
+		return
+	}
+
+	transitions.Add(ctx, 1, metric.WithAttributes(AttributeFromPhase.String(previous), AttributeToPhase.String(phase)))
+
+	object := []attribute.KeyValue{AttributeNamespace.String(m.object.GetNamespace()), AttributeName.String(m.object.GetName())}
+
+	if previous != "" {
+		current.Record(ctx, 0, metric.WithAttributes(append(object, AttributePhase.String(previous))...))
+	}
+
+	current.Record(ctx, 1, metric.WithAttributes(append(object, AttributePhase.String(phase))...))
+}
@@ -0,0 +1,74 @@
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWithMeterProvider(t *testing.T) {
+	ctx := context.Background()
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithMeterProvider(provider))
+
+	// Unknown, then Failed, then Failed again: two transitions, the last patch doesn't change the phase
+	for _, condition := range []Condition{
+		{Type: "A", Status: metav1.ConditionTrue, Reason: "Up"},
+		{Type: "B", Status: metav1.ConditionFalse, Reason: "Broken"},
+		{Type: "A", Status: metav1.ConditionFalse, Reason: "Broken"},
+	} {
+		if err := m.SetConditions(ctx, []Condition{condition}); err != nil {
+			t.Fatalf("SetConditions() error = %v", err)
+		}
+	}
+
+	var collected metricdata.ResourceMetrics
+	if err := reader.Collect(ctx, &collected); err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	transitions := map[[2]string]int64{}
+	phases := map[string]int64{}
+
+	for _, scope := range collected.ScopeMetrics {
+		for _, metric := range scope.Metrics {
+			switch data := metric.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					from, _ := point.Attributes.Value(AttributeFromPhase)
+					to, _ := point.Attributes.Value(AttributeToPhase)
+					transitions[[2]string{from.AsString(), to.AsString()}] = point.Value
+				}
+			case metricdata.Gauge[int64]:
+				for _, point := range data.DataPoints {
+					if name, _ := point.Attributes.Value(AttributeName); name != attribute.StringValue("test") {
+						t.Errorf("gauge for object %v, want test", name.AsString())
+					}
+					phase, _ := point.Attributes.Value(AttributePhase)
+					phases[phase.AsString()] = point.Value
+				}
+			}
+		}
+	}
+
+	wantTransitions := map[[2]string]int64{{"", "Unknown"}: 1, {"Unknown", "Failed"}: 1}
+	if len(transitions) != len(wantTransitions) {
+		t.Errorf("transitions = %v, want %v", transitions, wantTransitions)
+	}
+	for key, want := range wantTransitions {
+		if transitions[key] != want {
+			t.Errorf("transitions %v = %d, want %d", key, transitions[key], want)
+		}
+	}
+
+	if phases["Unknown"] != 0 || phases["Failed"] != 1 || len(phases) != 2 {
+		t.Errorf("phase gauge = %v, want Failed 1 and Unknown 0", phases)
+	}
+}
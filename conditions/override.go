@@ -0,0 +1,36 @@
+package conditions
+
+const (
+	// PhaseOverrideAnnotation on an object forces its phase to the annotation's value, regardless of the
+	// conditions and the phase rules, e.g. "Maintenance". Only read with WithOverrideAnnotations.
+	PhaseOverrideAnnotation = "phase-rules.debdutdeb.github.io/phase"
+
+	// UnmanagedAnnotation set to "true" on an object stops the manager from changing its phase, conditions are
+	// still set. Only read with WithOverrideAnnotations.
+	UnmanagedAnnotation = "phase-rules.debdutdeb.github.io/unmanaged"
+)
+
+// WithOverrideAnnotations lets single objects opt out of the phase rules through annotations, without changing the
+// rules of the kind. UnmanagedAnnotation takes precedence over PhaseOverrideAnnotation, which takes precedence
+// over the rules.
+func WithOverrideAnnotations() Option {
+	return func(m *ConditionsManager) {
+		m.overrideAnnotations = true
+	}
+}
+
+// annotatedPhase returns the phase the object's annotations force, and whether they leave the phase alone.
+// The phase is empty if the annotations don't force one.
+func (m *ConditionsManager) annotatedPhase() (phase string, unmanaged bool) {
+	if !m.overrideAnnotations {
+		return "", false
+	}
+
+	annotations := m.object.GetAnnotations()
+
+	if annotations[UnmanagedAnnotation] == "true" {
+		return "", true
+	}
+
+	return annotations[PhaseOverrideAnnotation], false
+}
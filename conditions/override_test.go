@@ -0,0 +1,74 @@
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWithOverrideAnnotations_ForcesPhase(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	obj.Annotations = map[string]string{PhaseOverrideAnnotation: "Maintenance"}
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithOverrideAnnotations())
+
+	for _, status := range []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse} {
+		if err := m.SetConditions(ctx, []Condition{
+			{Type: "A", Status: status, Reason: "Set"},
+			{Type: "B", Status: status, Reason: "Set"},
+		}); err != nil {
+			t.Fatalf("SetConditions() error = %v", err)
+		}
+
+		if obj.Status.Phase != "Maintenance" {
+			t.Errorf("Phase = %q with conditions %s, want the annotated %q", obj.Status.Phase, status, "Maintenance")
+		}
+	}
+
+	delete(obj.Annotations, PhaseOverrideAnnotation)
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionTrue, "Set", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+	if obj.Status.Phase != "Failed" {
+		t.Errorf("Phase = %q after removing the annotation, want the rules' %q", obj.Status.Phase, "Failed")
+	}
+}
+
+func TestWithOverrideAnnotations_Unmanaged(t *testing.T) {
+	ctx := context.Background()
+	obj := newTestObject(1)
+	obj.Status.Phase = "Pending"
+	obj.Annotations = map[string]string{
+		UnmanagedAnnotation:     "true",
+		PhaseOverrideAnnotation: "Maintenance",
+	}
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithOverrideAnnotations())
+
+	if err := m.SetCondition(ctx, "A", metav1.ConditionFalse, "Broken", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	if obj.Status.Phase != "Pending" {
+		t.Errorf("Phase = %q, want it left at %q", obj.Status.Phase, "Pending")
+	}
+	if !meta.IsStatusConditionFalse(obj.Status.Conditions, "A") {
+		t.Error("expected condition A to still be set")
+	}
+}
+
+func TestOverrideAnnotations_IgnoredByDefault(t *testing.T) {
+	obj := newTestObject(1)
+	obj.Annotations = map[string]string{PhaseOverrideAnnotation: "Maintenance"}
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules)
+
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionFalse, "Broken", ""); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	if obj.Status.Phase != "Failed" {
+		t.Errorf("Phase = %q, want %q", obj.Status.Phase, "Failed")
+	}
+}
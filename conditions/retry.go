@@ -0,0 +1,48 @@
+package conditions
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WithConflictRetry retries status patches rejected with a conflict, per backoff, e.g. retry.DefaultRetry.
+// Before every retry the object is read again with reader and the update, conditions or forced phase, is
+// applied to it afresh; if the update turns out to change nothing on the latest object, there's nothing to
+// patch. Without this option a conflict is returned to the caller.
+func WithConflictRetry(reader client.Reader, backoff wait.Backoff) Option {
+	return func(m *ConditionsManager) {
+		m.conflictReader = reader
+		m.conflictBackoff = backoff
+	}
+}
+
+// patchStatusWithRetry patches the object's status against base, and with WithConflictRetry retries conflicts on
+// the object read again, with the update applied to it again by reapply, which reports whether anything changed.
+func (m *ConditionsManager) patchStatusWithRetry(ctx context.Context, base client.Object, reapply func() bool) error {
+	if m.conflictReader == nil {
+		return m.patchStatus(ctx, base)
+	}
+
+	attempt := 0
+
+	return retry.RetryOnConflict(m.conflictBackoff, func() error {
+		attempt++
+
+		if attempt > 1 {
+			if err := m.conflictReader.Get(ctx, client.ObjectKeyFromObject(m.object), m.object); err != nil {
+				return err
+			}
+
+			base = m.object.DeepCopyObject().(client.Object)
+
+			if !reapply() {
+				return nil
+			}
+		}
+
+		return m.patchStatus(ctx, base)
+	})
+}
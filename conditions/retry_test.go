@@ -0,0 +1,107 @@
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// conflictingStatusClient rejects the first status patches with a conflict, then records them.
+type conflictingStatusClient struct {
+	fakeStatusClient
+
+	conflicts int
+}
+
+func (c *conflictingStatusClient) Status() client.SubResourceWriter {
+	return &conflictingStatusWriter{fakeStatusWriter: fakeStatusWriter{client: &c.fakeStatusClient}, client: c}
+}
+
+type conflictingStatusWriter struct {
+	fakeStatusWriter
+
+	client *conflictingStatusClient
+}
+
+func (w *conflictingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if w.client.conflicts > 0 {
+		w.client.conflicts--
+		return apierrors.NewConflict(schema.GroupResource{Resource: "tests"}, obj.GetName(), nil)
+	}
+
+	return w.fakeStatusWriter.Patch(ctx, obj, patch, opts...)
+}
+
+// objectReader reads the latest object, as another writer left it.
+type objectReader struct {
+	client.Reader
+
+	latest *testObject
+	gets   int
+}
+
+func (r *objectReader) Get(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	r.gets++
+	*obj.(*testObject) = *r.latest.DeepCopyObject().(*testObject)
+	return nil
+}
+
+func TestWithConflictRetry(t *testing.T) {
+	obj := newTestObject(1)
+	latest := newTestObject(1)
+	latest.Status.Conditions = []metav1.Condition{{Type: "B", Status: metav1.ConditionTrue, Reason: "Done", LastTransitionTime: metav1.Now()}}
+
+	statusClient := &conflictingStatusClient{conflicts: 1}
+	reader := &objectReader{latest: latest}
+	m := NewManager(statusClient, &obj.Status.Conditions, obj, testRules, WithConflictRetry(reader, retry.DefaultRetry))
+
+	if err := m.SetCondition(context.Background(), "A", metav1.ConditionTrue, "Done", "a is done"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	if reader.gets != 1 {
+		t.Errorf("got %d reads, want the object read again once", reader.gets)
+	}
+	if len(statusClient.patches) != 1 {
+		t.Fatalf("got %d patches, want the second attempt to succeed", len(statusClient.patches))
+	}
+
+	// the condition is applied to the latest object, next to the condition set by another writer
+	if !meta.IsStatusConditionTrue(obj.Status.Conditions, "A") || !meta.IsStatusConditionTrue(obj.Status.Conditions, "B") {
+		t.Errorf("conditions = %v, want A and B True", obj.Status.Conditions)
+	}
+	if obj.Status.Phase != "Ready" {
+		t.Errorf("Phase = %q, want %q", obj.Status.Phase, "Ready")
+	}
+}
+
+func TestWithoutConflictRetry(t *testing.T) {
+	obj := newTestObject(1)
+	m := NewManager(&conflictingStatusClient{conflicts: 1}, &obj.Status.Conditions, obj, testRules)
+
+	err := m.SetCondition(context.Background(), "A", metav1.ConditionTrue, "Done", "a is done")
+	if !apierrors.IsConflict(err) {
+		t.Errorf("SetCondition() error = %v, want the conflict", err)
+	}
+}
+
+func TestWithConflictRetry_GivesUp(t *testing.T) {
+	obj := newTestObject(1)
+	reader := &objectReader{latest: newTestObject(1)}
+	backoff := retry.DefaultRetry
+	backoff.Steps = 2
+	m := NewManager(&conflictingStatusClient{conflicts: 5}, &obj.Status.Conditions, obj, testRules, WithConflictRetry(reader, backoff))
+
+	if err := m.SetConditions(context.Background(), []Condition{{Type: "A", Status: metav1.ConditionTrue, Reason: "Done"}}); !apierrors.IsConflict(err) {
+		t.Errorf("SetConditions() error = %v, want the conflict after the attempts run out", err)
+	}
+	if reader.gets != 1 {
+		t.Errorf("got %d reads, want 1 for 2 attempts", reader.gets)
+	}
+}
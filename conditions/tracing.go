@@ -0,0 +1,36 @@
+package conditions
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/debdutdeb/kubernetes-phase-rules/conditions"
+
+// Span attributes set on the ComputePhase span.
+const (
+	AttributePhase          = attribute.Key("phase")
+	AttributeRulesEvaluated = attribute.Key("phase.rules_evaluated")
+)
+
+// WithTracerProvider makes the manager record a "ComputePhase" span, a child of the span in the context passed
+// to SetConditions or SetCondition, for every phase computation. The span carries the computed phase, the number
+// of rules evaluated. Only the OpenTelemetry trace API is used,
+// pass the provider of whichever SDK the controller is set up with, e.g. otel.GetTracerProvider().
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(m *ConditionsManager) {
+		m.tracerProvider = provider
+	}
+}
+
+// traceEvaluation records the outcome of a phase computation on span, nil without tracing.
+func traceEvaluation(span trace.Span, phase string, evaluated int) {
+	if span == nil {
+		return
+	}
+
+	span.SetAttributes(
+		AttributePhase.String(phase),
+		AttributeRulesEvaluated.Int(evaluated),
+	)
+}
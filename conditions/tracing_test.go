@@ -0,0 +1,55 @@
+package conditions
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "Reconcile")
+
+	obj := newTestObject(1)
+	m := NewManager(&fakeStatusClient{}, &obj.Status.Conditions, obj, testRules, WithTracerProvider(provider))
+
+	// Ready is not satisfied, Failed is: two rules evaluated
+	if err := m.SetCondition(ctx, "A", metav1.ConditionFalse, "Broken", "a broke"); err != nil {
+		t.Fatalf("SetCondition() error = %v", err)
+	}
+
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want the ComputePhase and the parent span", len(spans))
+	}
+
+	span := spans[0]
+	if span.Name() != "ComputePhase" {
+		t.Fatalf("span name = %q, want %q", span.Name(), "ComputePhase")
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("expected ComputePhase to be a child of the span in the context")
+	}
+
+	attributes := map[string]any{}
+	for _, kv := range span.Attributes() {
+		attributes[string(kv.Key)] = kv.Value.AsInterface()
+	}
+
+	want := map[string]any{
+		string(AttributePhase):          "Failed",
+		string(AttributeRulesEvaluated): int64(2),
+	}
+	for key, value := range want {
+		if attributes[key] != value {
+			t.Errorf("attribute %s = %v, want %v", key, attributes[key], value)
+		}
+	}
+}
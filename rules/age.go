@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// Clock supplies the current time to the matchers comparing against it.
+type Clock interface {
+	Now() metav1.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() metav1.Time {
+	return metav1.Now()
+}
+
+// AgeMatcher is a ConditionMatcher comparing how long a condition has held its status,
+// since its LastTransitionTime, against a duration.
+type AgeMatcher interface {
+	ConditionMatcher
+
+	// WithClock returns the matcher reading the current time from clock instead of the wall clock, e.g. in tests.
+	WithClock(clock Clock) AgeMatcher
+}
+
+type conditionAgeMatcher struct {
+	condition string
+	duration  time.Duration
+	younger   bool
+	clock     Clock
+}
+
+var _ AgeMatcher = (*conditionAgeMatcher)(nil)
+
+func (m *conditionAgeMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	now := m.clock.Now()
+
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		if condition.LastTransitionTime.IsZero() {
+			// no transition time, no age
+			return false
+		}
+
+		age := now.Sub(condition.LastTransitionTime.Time)
+
+		if m.younger {
+			return age < m.duration
+		}
+
+		return age >= m.duration
+	})
+}
+
+func (m *conditionAgeMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+func (m *conditionAgeMatcher) WithClock(clock Clock) AgeMatcher {
+	clocked := *m
+	clocked.clock = clock
+
+	return &clocked
+}
+
+// ConditionYoungerThan returns a matcher for a condition type whose status last transitioned less than d ago,
+// whatever the status, e.g. for a "Stabilizing" phase. A condition without a LastTransitionTime never matches.
+// The result changes as time passes while the conditions don't, mind that the manager only recomputes the phase
+// when conditions change: requeue the object at NextEvaluationTime.
+func ConditionYoungerThan(condition string, d time.Duration) AgeMatcher {
+	return &conditionAgeMatcher{
+		condition: condition,
+		duration:  d,
+		younger:   true,
+		clock:     realClock{},
+	}
+}
+
+// ConditionOlderThan returns a matcher for a condition type whose status last transitioned d or more ago,
+// the complement of ConditionYoungerThan for a condition with a LastTransitionTime; the same caveats apply.
+func ConditionOlderThan(condition string, d time.Duration) AgeMatcher {
+	return &conditionAgeMatcher{
+		condition: condition,
+		duration:  d,
+		clock:     realClock{},
+	}
+}
+
+// WithClock returns a copy of ctx carrying clock, for ComputePhaseContext to read the current time from instead of
+// the wall clock, e.g. a fixed clock in tests.
+func WithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+type clockKey struct{}
+
+// ComputePhaseContext is rule.ComputePhase with the age matchers reading the current time from the clock in ctx,
+// set with WithClock, and from the wall clock without one, so rules can be built without a clock. Age matchers given
+// a clock of their own with AgeMatcher.WithClock keep it. The rule is left as it is.
+func ComputePhaseContext(ctx context.Context, rule PhaseRule, conditions *[]metav1.Condition) string {
+	clock, ok := ctx.Value(clockKey{}).(Clock)
+	if !ok {
+		return rule.ComputePhase(conditions)
+	}
+
+	clocked := rule.DeepCopy()
+
+	for _, m := range ageMatchers(clocked) {
+		if _, ok := m.clock.(realClock); ok {
+			m.clock = clock
+		}
+	}
+
+	return clocked.ComputePhase(conditions)
+}
+
+// NextEvaluationTime returns the earliest moment after now at which an age matcher of the rules changes its result for
+// the conditions, when a condition it looks at turns its duration old, so a controller can requeue the object exactly
+// then. It is the zero time if none will, e.g. without age matchers or once every duration has passed. A condition
+// changing status in the meantime resets its age, the time is only good until the conditions change.
+func NextEvaluationTime(rules []PhaseRule, conditions *[]metav1.Condition, now time.Time) time.Time {
+	var next time.Time
+
+	if conditions == nil {
+		return next
+	}
+
+	for _, rule := range rules {
+		for _, m := range ageMatchers(rule) {
+			for _, condition := range *conditions {
+				if condition.Type != m.condition || condition.LastTransitionTime.IsZero() {
+					continue
+				}
+
+				if at := condition.LastTransitionTime.Add(m.duration); at.After(now) {
+					next = earliest(next, at)
+				}
+			}
+		}
+	}
+
+	return next
+}
+
+// ageMatchers returns the age matchers of rule, nested or not.
+func ageMatchers(rule PhaseRule) []*conditionAgeMatcher {
+	switch r := rule.(type) {
+	case *phaseRuleSimple:
+		return matcherAgeMatchers(r.matcher)
+	case *phaseRuleNegated:
+		return ageMatchers(r.base)
+	case *phaseRuleCombined:
+		var found []*conditionAgeMatcher
+		for _, combined := range r.rules {
+			found = append(found, ageMatchers(combined)...)
+		}
+
+		return found
+	default:
+		return nil
+	}
+}
+
+func matcherAgeMatchers(matcher ConditionMatcher) []*conditionAgeMatcher {
+	var children []ConditionMatcher
+
+	switch m := matcher.(type) {
+	case *conditionAgeMatcher:
+		return []*conditionAgeMatcher{m}
+	case *conditionMatcherAll:
+		children = m.matcherReferences
+	case *conditionMatcherAny:
+		children = m.matcherReferences
+	case *conditionMatcherAnyResolved:
+		children = m.matcherReferences
+	case *conditionMatcherAtLeast:
+		children = m.matcherReferences
+	case *conditionMatcherWeighted:
+		for _, w := range m.weighted {
+			children = append(children, w.Matcher)
+		}
+	case *conditionMatcherDominant:
+		children = []ConditionMatcher{m.dominant, m.rest}
+	}
+
+	var found []*conditionAgeMatcher
+	for _, child := range children {
+		found = append(found, matcherAgeMatchers(child)...)
+	}
+
+	return found
+}
+
+// earliest returns the earlier of a and b, the zero time standing for never.
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fixedClock is a Clock always returning the same time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() metav1.Time { return metav1.NewTime(time.Time(c)) }
+
+func TestConditionAge_Boundary(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := fixedClock(now)
+
+	younger := ConditionYoungerThan("Available", time.Minute).WithClock(clock)
+	older := ConditionOlderThan("Available", time.Minute).WithClock(clock)
+
+	tests := []struct {
+		name        string
+		age         time.Duration
+		wantYounger MatchResult
+		wantOlder   MatchResult
+	}{
+		{"just transitioned", 0, MatcherMatched, MatcherNotMatched},
+		{"below the threshold", time.Minute - time.Second, MatcherMatched, MatcherNotMatched},
+		{"exactly the threshold", time.Minute, MatcherNotMatched, MatcherMatched},
+		{"above the threshold", time.Hour, MatcherNotMatched, MatcherMatched},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conds := []metav1.Condition{{
+				Type:               "Available",
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(now.Add(-tt.age)),
+			}}
+
+			if got := younger.Matches(&conds); got != tt.wantYounger {
+				t.Errorf("ConditionYoungerThan().Matches() = %v, want %v", got, tt.wantYounger)
+			}
+			if got := older.Matches(&conds); got != tt.wantOlder {
+				t.Errorf("ConditionOlderThan().Matches() = %v, want %v", got, tt.wantOlder)
+			}
+		})
+	}
+}
+
+func TestConditionAge_Stabilizing(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	computer := NewPhaseComputer(
+		NewPhaseRule("Stabilizing", ConditionsAll(
+			ConditionEquals("Available", metav1.ConditionTrue),
+			ConditionYoungerThan("Available", 5*time.Minute).WithClock(fixedClock(now)),
+		)),
+		NewPhaseRule("Ready", ConditionEquals("Available", metav1.ConditionTrue)),
+	)
+
+	conds := []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))}}
+	if got := computer.Compute(&conds); got != "Stabilizing" {
+		t.Errorf("Compute() = %q, want %q", got, "Stabilizing")
+	}
+
+	conds[0].LastTransitionTime = metav1.NewTime(now.Add(-5 * time.Minute))
+	if got := computer.Compute(&conds); got != "Ready" {
+		t.Errorf("Compute() = %q, want %q", got, "Ready")
+	}
+}
+
+func TestConditionAge_NoTransitionTime(t *testing.T) {
+	older := ConditionOlderThan("Available", time.Minute)
+
+	conds := []metav1.Condition{cond("Available", metav1.ConditionTrue)}
+	if got := older.Matches(&conds); got != MatcherNotMatched {
+		t.Errorf("Matches() = %v, want %v without a transition time", got, MatcherNotMatched)
+	}
+	if got := older.Matches(&[]metav1.Condition{}); got != MatcherUnknown {
+		t.Errorf("Matches() = %v, want %v when condition is missing", got, MatcherUnknown)
+	}
+}
+
+func TestNextEvaluationTime_Stuck(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	phaseRules := []PhaseRule{
+		NewPhaseRule("Stuck", ConditionsAll(
+			ConditionEquals("Progressing", metav1.ConditionTrue),
+			ConditionOlderThan("Progressing", 10*time.Minute).WithClock(fixedClock(now)),
+		)),
+		NewPhaseRule("Ready", ConditionEquals("Available", metav1.ConditionTrue)),
+	}
+
+	conds := []metav1.Condition{{Type: "Progressing", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-3 * time.Minute))}}
+	if got, want := NextEvaluationTime(phaseRules, &conds, now), now.Add(7*time.Minute); !got.Equal(want) {
+		t.Errorf("NextEvaluationTime() = %v, want %v", got, want)
+	}
+
+	conds[0].LastTransitionTime = metav1.NewTime(now.Add(-10 * time.Minute))
+	if got := NextEvaluationTime(phaseRules, &conds, now); !got.IsZero() {
+		t.Errorf("NextEvaluationTime() = %v, want none once stuck", got)
+	}
+}
+
+func TestNextEvaluationTime_Earliest(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	phaseRules := []PhaseRule{
+		Negate(NewPhaseRule("Settled", ConditionOlderThan("A", time.Hour)), "Settling"),
+		CombineRules("Stabilizing",
+			NewPhaseRule("Stabilizing", ConditionYoungerThan("B", 5*time.Minute)),
+			NewPhaseRule("Stabilizing", ConditionsAtLeast(1, ConditionYoungerThan("C", 5*time.Minute))),
+		),
+	}
+
+	conds := []metav1.Condition{
+		{Type: "A", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now)},
+		{Type: "B", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))},
+		{Type: "C", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-2 * time.Minute))},
+	}
+	if got, want := NextEvaluationTime(phaseRules, &conds, now), now.Add(3*time.Minute); !got.Equal(want) {
+		t.Errorf("NextEvaluationTime() = %v, want %v", got, want)
+	}
+
+	if got := NextEvaluationTime([]PhaseRule{NewPhaseRule("Ready", ConditionEquals("A", metav1.ConditionTrue))}, &conds, now); !got.IsZero() {
+		t.Errorf("NextEvaluationTime() = %v, want none without age matchers", got)
+	}
+}
+
+func TestComputePhaseContext_Clock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	rule := NewPhaseRule("Stabilizing", ConditionYoungerThan("Available", 5*time.Minute))
+	conds := []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue, LastTransitionTime: metav1.NewTime(now.Add(-time.Minute))}}
+
+	ctx := WithClock(context.Background(), fixedClock(now))
+	if got := ComputePhaseContext(ctx, rule, &conds); got != "Stabilizing" {
+		t.Errorf("ComputePhaseContext() = %q, want %q at the context's time", got, "Stabilizing")
+	}
+
+	// the wall clock is years past the transition
+	if got := ComputePhaseContext(context.Background(), rule, &conds); got != PhaseUnknown {
+		t.Errorf("ComputePhaseContext() = %q, want %q without a clock", got, PhaseUnknown)
+	}
+	if got := rule.ComputePhase(&conds); got != PhaseUnknown {
+		t.Errorf("ComputePhase() = %q, want %q, the rule is left on the wall clock", got, PhaseUnknown)
+	}
+
+	own := NewPhaseRule("Stabilizing", ConditionYoungerThan("Available", 5*time.Minute).WithClock(fixedClock(now.Add(time.Hour))))
+	if got := ComputePhaseContext(ctx, own, &conds); got != PhaseUnknown {
+		t.Errorf("ComputePhaseContext() = %q, want %q, a matcher keeps its own clock", got, PhaseUnknown)
+	}
+}
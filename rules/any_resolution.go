@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// AnyResolution orders condition statuses from strongest to weakest, to pick which of several matching
+// conditions drove a ConditionsAnyResolved match. Statuses not listed rank below every listed one.
+type AnyResolution []metav1.ConditionStatus
+
+// DefaultAnyResolution ranks True over Unknown over False.
+var DefaultAnyResolution = AnyResolution{metav1.ConditionTrue, metav1.ConditionUnknown, metav1.ConditionFalse}
+
+func (r AnyResolution) strength(status metav1.ConditionStatus) int {
+	if i := slices.Index(r, status); i >= 0 {
+		return len(r) - i
+	}
+
+	return 0
+}
+
+// ResolvedAnyMatcher is a ConditionsAny matcher that can also report which condition won the match.
+type ResolvedAnyMatcher interface {
+	ConditionMatcher
+
+	// Resolve returns the strongest condition among those referenced by the matching alternatives,
+	// and false if no alternative matches or the matching ones declare no condition types.
+	// Referenced condition types missing from conditions are resolved as Unknown, the same way a phase rule evaluates them.
+	Resolve(conditions *[]metav1.Condition) (metav1.Condition, bool)
+}
+
+type conditionMatcherAnyResolved struct {
+	conditionMatcherAny
+
+	resolution AnyResolution
+}
+
+var _ ResolvedAnyMatcher = (*conditionMatcherAnyResolved)(nil)
+
+// ConditionsAnyResolved returns a matcher that matches like ConditionsAny, and resolves the winning condition
+// by the status strength in resolution; ties go to the condition listed first.
+// Pass DefaultAnyResolution for True > Unknown > False.
+func ConditionsAnyResolved(resolution AnyResolution, matchers ...ConditionMatcher) ResolvedAnyMatcher {
+	return &conditionMatcherAnyResolved{
+		conditionMatcherAny: conditionMatcherAny{
+			matcherReferences: matchers,
+		},
+		resolution: resolution,
+	}
+}
+
+func (m *conditionMatcherAnyResolved) Resolve(conditions *[]metav1.Condition) (metav1.Condition, bool) {
+	if conditions == nil {
+		return metav1.Condition{}, false
+	}
+
+	stateConditions, missing := withUnknownConditions(*conditions, m.ConditionTypes())
+
+	candidates := sets.New[string]()
+	matched := false
+
+	for _, matcher := range m.matcherReferences {
+		if matches(matcher, &stateConditions, missing) == MatcherMatched {
+			matched = true
+			candidates.DestructiveUnion(matcher.ConditionTypes())
+		}
+	}
+
+	if !matched {
+		return metav1.Condition{}, false
+	}
+
+	var (
+		winner metav1.Condition
+		found  bool
+	)
+
+	for _, condition := range stateConditions {
+		if !candidates.Has(condition.Type) {
+			continue
+		}
+
+		if !found || m.resolution.strength(condition.Status) > m.resolution.strength(winner.Status) {
+			winner, found = condition, true
+		}
+	}
+
+	return winner, found
+}
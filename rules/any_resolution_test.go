@@ -0,0 +1,67 @@
+package rules
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionsAnyResolved_Resolve(t *testing.T) {
+	matcher := ConditionsAnyResolved(DefaultAnyResolution,
+		ConditionEquals("A", metav1.ConditionTrue, metav1.ConditionUnknown),
+		ConditionEquals("B", metav1.ConditionTrue, metav1.ConditionUnknown),
+	)
+
+	tests := []struct {
+		name       string
+		conds      []metav1.Condition
+		wantType   string
+		wantStatus metav1.ConditionStatus
+		wantOK     bool
+	}{
+		{"true beats unknown", []metav1.Condition{cond("A", metav1.ConditionUnknown), cond("B", metav1.ConditionTrue)}, "B", metav1.ConditionTrue, true},
+		{"tie goes to the first", []metav1.Condition{cond("B", metav1.ConditionTrue), cond("A", metav1.ConditionTrue)}, "B", metav1.ConditionTrue, true},
+		{"missing resolves as unknown", []metav1.Condition{cond("A", metav1.ConditionFalse)}, "B", metav1.ConditionUnknown, true},
+		{"non matching alternatives are ignored", []metav1.Condition{cond("A", metav1.ConditionFalse), cond("B", metav1.ConditionUnknown)}, "B", metav1.ConditionUnknown, true},
+		{"no match", []metav1.Condition{cond("A", metav1.ConditionFalse), cond("B", metav1.ConditionFalse)}, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			winner, ok := matcher.Resolve(&tt.conds)
+			if ok != tt.wantOK || winner.Type != tt.wantType || winner.Status != tt.wantStatus {
+				t.Errorf("Resolve() = (%s=%s, %v), want (%s=%s, %v)", winner.Type, winner.Status, ok, tt.wantType, tt.wantStatus, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestConditionsAnyResolved_CustomResolution(t *testing.T) {
+	// a failure-first ordering reports the False condition when both alternatives match
+	matcher := ConditionsAnyResolved(AnyResolution{metav1.ConditionFalse, metav1.ConditionTrue},
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("B", metav1.ConditionFalse),
+	)
+
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse)}
+
+	winner, ok := matcher.Resolve(&conds)
+	if !ok || winner.Type != "B" {
+		t.Errorf("Resolve() = (%s, %v), want (B, true)", winner.Type, ok)
+	}
+}
+
+func TestConditionsAnyResolved_PhaseRule(t *testing.T) {
+	rule := NewPhaseRule("Progressing", ConditionsAnyResolved(DefaultAnyResolution,
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("B", metav1.ConditionUnknown),
+	))
+
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionUnknown)}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when both alternatives match")
+	}
+	if rule.Satisfies(nil) {
+		t.Error("expected false when conditions is nil")
+	}
+}
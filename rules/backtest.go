@@ -0,0 +1,34 @@
+package rules
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PhaseDiff is a snapshot for which two sets of rules compute different phases.
+type PhaseDiff struct {
+	// Snapshot is the index of the snapshot in the back-tested snapshots.
+	Snapshot int
+
+	OldPhase string
+	NewPhase string
+}
+
+// BackTest replays recorded condition snapshots against oldRules and newRules, first matching rule wins,
+// and returns a diff for each snapshot whose phase changes, in snapshot order. No diffs means the new rules
+// agree with the old ones on every snapshot.
+func BackTest(oldRules, newRules []PhaseRule, snapshots [][]metav1.Condition) []PhaseDiff {
+	var diffs []PhaseDiff
+
+	oldComputer, newComputer := NewPhaseComputer(oldRules...), NewPhaseComputer(newRules...)
+
+	for i := range snapshots {
+		oldPhase := oldComputer.Compute(&snapshots[i])
+		newPhase := newComputer.Compute(&snapshots[i])
+
+		if oldPhase != newPhase {
+			diffs = append(diffs, PhaseDiff{Snapshot: i, OldPhase: oldPhase, NewPhase: newPhase})
+		}
+	}
+
+	return diffs
+}
@@ -0,0 +1,47 @@
+package rules
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBackTest(t *testing.T) {
+	oldRules := []PhaseRule{
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue))),
+		NewPhaseRule("Failed", ConditionsAll(ConditionEquals("A", metav1.ConditionFalse))),
+	}
+	// the new rules also require B before Ready
+	newRules := []PhaseRule{
+		NewPhaseRule("Ready", ConditionsAll(
+			ConditionEquals("A", metav1.ConditionTrue),
+			ConditionEquals("B", metav1.ConditionTrue),
+		)),
+		NewPhaseRule("Failed", ConditionsAll(ConditionEquals("A", metav1.ConditionFalse))),
+	}
+
+	snapshots := [][]metav1.Condition{
+		{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue)},
+		{cond("A", metav1.ConditionTrue)},
+		{cond("A", metav1.ConditionFalse)},
+		{},
+	}
+
+	diffs := BackTest(oldRules, newRules, snapshots)
+	if len(diffs) != 1 {
+		t.Fatalf("BackTest() = %v, want a single diff", diffs)
+	}
+
+	want := PhaseDiff{Snapshot: 1, OldPhase: "Ready", NewPhase: PhaseUnknown}
+	if diffs[0] != want {
+		t.Errorf("BackTest() diff = %+v, want %+v", diffs[0], want)
+	}
+}
+
+func TestBackTest_SameRules(t *testing.T) {
+	phaseRules := []PhaseRule{NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue)))}
+
+	if diffs := BackTest(phaseRules, phaseRules, [][]metav1.Condition{{cond("A", metav1.ConditionTrue)}, nil}); len(diffs) != 0 {
+		t.Errorf("BackTest() = %v, want no diffs", diffs)
+	}
+}
@@ -0,0 +1,123 @@
+package rules
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RuleBuilder builds a phase rule one matcher at a time, e.g.
+//
+//	NewRuleBuilder("Ready").
+//		Equals("Available", metav1.ConditionTrue).
+//		AnyOf(func(b *RuleBuilder) {
+//			b.NotEquals("Degraded", metav1.ConditionTrue).ReasonIs("Degraded", "Ignored")
+//		}).
+//		Build()
+//
+// The matchers added to a builder are combined with ConditionsAll, or ConditionsAny after AnyOf without a function.
+type RuleBuilder struct {
+	phase    string
+	priority int
+	any      bool
+	matchers []ConditionMatcher
+	opts     []RuleOption
+}
+
+func NewRuleBuilder(phase string) *RuleBuilder {
+	return &RuleBuilder{phase: phase}
+}
+
+// Priority sets the priority of the rule, see NewPhaseRuleWithPriority.
+func (b *RuleBuilder) Priority(priority int) *RuleBuilder {
+	b.priority = priority
+	return b
+}
+
+// Summary gives the rule a reason and message explaining its phase, see WithSummary.
+func (b *RuleBuilder) Summary(reason, message string) *RuleBuilder {
+	b.opts = append(b.opts, WithSummary(reason, message))
+	return b
+}
+
+// AllOf combines the builder's matchers with ConditionsAll, the default. Given nested, it instead adds a
+// ConditionsAll of the matchers nested adds to the builder it is passed.
+func (b *RuleBuilder) AllOf(nested ...func(b *RuleBuilder)) *RuleBuilder {
+	if len(nested) == 0 {
+		b.any = false
+		return b
+	}
+
+	return b.Matcher(ConditionsAll(b.nested(nested)...))
+}
+
+// AnyOf combines the builder's matchers with ConditionsAny. Given nested, it instead adds a ConditionsAny of the
+// matchers nested adds to the builder it is passed.
+func (b *RuleBuilder) AnyOf(nested ...func(b *RuleBuilder)) *RuleBuilder {
+	if len(nested) == 0 {
+		b.any = true
+		return b
+	}
+
+	return b.Matcher(ConditionsAny(b.nested(nested)...))
+}
+
+// AtLeast adds a ConditionsAtLeast of n of the matchers nested adds to the builder it is passed.
+func (b *RuleBuilder) AtLeast(n int, nested func(b *RuleBuilder)) *RuleBuilder {
+	return b.Matcher(ConditionsAtLeast(n, b.nested([]func(*RuleBuilder){nested})...))
+}
+
+func (b *RuleBuilder) nested(nested []func(b *RuleBuilder)) []ConditionMatcher {
+	child := NewRuleBuilder(b.phase)
+
+	for _, build := range nested {
+		build(child)
+	}
+
+	return child.matchers
+}
+
+// Equals adds ConditionEquals.
+func (b *RuleBuilder) Equals(condition string, statuses ...metav1.ConditionStatus) *RuleBuilder {
+	return b.Matcher(ConditionEquals(condition, statuses...))
+}
+
+// NotEquals adds ConditionNotEquals.
+func (b *RuleBuilder) NotEquals(condition string, statuses ...metav1.ConditionStatus) *RuleBuilder {
+	return b.Matcher(ConditionNotEquals(condition, statuses...))
+}
+
+// ReasonIs adds ConditionReasonIs.
+func (b *RuleBuilder) ReasonIs(condition string, reasons ...string) *RuleBuilder {
+	return b.Matcher(ConditionReasonIs(condition, reasons...))
+}
+
+// ReasonEquals adds ConditionReasonEquals.
+func (b *RuleBuilder) ReasonEquals(condition string, status metav1.ConditionStatus, reasons ...string) *RuleBuilder {
+	return b.Matcher(ConditionReasonEquals(condition, status, reasons...))
+}
+
+// Exists adds ConditionExists.
+func (b *RuleBuilder) Exists(condition string) *RuleBuilder {
+	return b.Matcher(ConditionExists(condition))
+}
+
+// Missing adds ConditionMissing.
+func (b *RuleBuilder) Missing(condition string) *RuleBuilder {
+	return b.Matcher(ConditionMissing(condition))
+}
+
+// Matcher adds any other matcher, e.g. ConditionFresh or a custom one.
+func (b *RuleBuilder) Matcher(matcher ConditionMatcher) *RuleBuilder {
+	b.matchers = append(b.matchers, matcher)
+	return b
+}
+
+// Build returns the rule. The builder can be built again, later changes to it don't affect rules built before.
+func (b *RuleBuilder) Build() PhaseRule {
+	matchers := deepCopyMatchers(b.matchers)
+
+	if b.any {
+		return NewPhaseRuleWithPriority(b.phase, b.priority, ConditionsAny(matchers...), b.opts...)
+	}
+
+	return NewPhaseRuleWithPriority(b.phase, b.priority, ConditionsAll(matchers...), b.opts...)
+}
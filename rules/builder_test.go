@@ -0,0 +1,70 @@
+package rules
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRuleBuilder_MatchesHandBuiltRule(t *testing.T) {
+	built := NewRuleBuilder("Ready").
+		Priority(2).
+		AllOf().
+		Equals("A", metav1.ConditionTrue).
+		AnyOf(func(b *RuleBuilder) {
+			b.NotEquals("B", metav1.ConditionTrue).ReasonIs("B", "Ignored")
+		}).
+		AtLeast(1, func(b *RuleBuilder) {
+			b.Exists("C").Missing("D")
+		}).
+		Build()
+
+	want := NewPhaseRuleWithPriority("Ready", 2, ConditionsAll(
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionsAny(
+			ConditionNotEquals("B", metav1.ConditionTrue),
+			ConditionReasonIs("B", "Ignored"),
+		),
+		ConditionsAtLeast(1, ConditionExists("C"), ConditionMissing("D")),
+	))
+
+	if !reflect.DeepEqual(built, want) {
+		t.Errorf("Build() = %#v, want %#v", built, want)
+	}
+}
+
+func TestRuleBuilder_AnyOf(t *testing.T) {
+	rule := NewRuleBuilder("Failed").
+		AnyOf().
+		Equals("A", metav1.ConditionFalse).
+		ReasonEquals("B", metav1.ConditionFalse, "Crashed").
+		Build()
+
+	for _, tt := range []struct {
+		conds []metav1.Condition
+		want  bool
+	}{
+		{[]metav1.Condition{cond("A", metav1.ConditionFalse), cond("B", metav1.ConditionTrue)}, true},
+		{[]metav1.Condition{cond("A", metav1.ConditionTrue), {Type: "B", Status: metav1.ConditionFalse, Reason: "Crashed"}}, true},
+		{[]metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse)}, false},
+	} {
+		if got := rule.Satisfies(&tt.conds); got != tt.want {
+			t.Errorf("Satisfies(%v) = %v, want %v", tt.conds, got, tt.want)
+		}
+	}
+}
+
+func TestRuleBuilder_BuildAgain(t *testing.T) {
+	b := NewRuleBuilder("Ready").Equals("A", metav1.ConditionTrue)
+	first := b.Build()
+	b.Equals("B", metav1.ConditionTrue)
+
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue)}
+	if !first.Satisfies(&conds) {
+		t.Error("adding to the builder changed a rule built before")
+	}
+	if b.Build().Satisfies(&conds) {
+		t.Error("expected the rebuilt rule to require B")
+	}
+}
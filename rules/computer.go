@@ -0,0 +1,140 @@
+package rules
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// PhaseComputer evaluates an ordered list of phase rules, the first satisfied rule decides the phase.
+// Rules are evaluated from the highest to the lowest priority, rules of equal priority in the order given.
+type PhaseComputer struct {
+	rules        []PhaseRule
+	defaultPhase string
+
+	// the condition types and prefixes the rules read, or all of them
+	types    sets.Set[string]
+	prefixes []string
+	readsAll bool
+}
+
+func NewPhaseComputer(rules ...PhaseRule) *PhaseComputer {
+	return NewPhaseComputerWithDefault(PhaseUnknown, rules...)
+}
+
+// NewPhaseComputerWithDefault returns a computer falling back to defaultPhase instead of PhaseUnknown
+// when no rule is satisfied, e.g. "Pending".
+func NewPhaseComputerWithDefault(defaultPhase string, rules ...PhaseRule) *PhaseComputer {
+	ordered := slices.Clone(rules)
+	slices.SortStableFunc(ordered, func(a, b PhaseRule) int {
+		return cmp.Compare(b.Priority(), a.Priority())
+	})
+
+	c := &PhaseComputer{
+		rules:        ordered,
+		defaultPhase: defaultPhase,
+		types:        sets.New[string](),
+	}
+
+	for _, rule := range ordered {
+		c.addRuleDependencies(rule)
+	}
+
+	return c
+}
+
+// Compute returns the phase of the first rule satisfied by the conditions, or the default phase.
+func (c *PhaseComputer) Compute(conditions *[]metav1.Condition) string {
+	if i := c.Match(conditions); i >= 0 {
+		return c.rules[i].Phase()
+	}
+
+	return c.defaultPhase
+}
+
+// Match returns the index in Rules of the first rule satisfied by the conditions, -1 if none is.
+// The rules after it are not evaluated.
+func (c *PhaseComputer) Match(conditions *[]metav1.Condition) int {
+	for i, rule := range c.rules {
+		if rule.Satisfies(conditions) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// DefaultPhase returns the phase when no rule is satisfied, PhaseUnknown unless configured otherwise.
+func (c *PhaseComputer) DefaultPhase() string {
+	return c.defaultPhase
+}
+
+// Rules returns the rules in evaluation order.
+func (c *PhaseComputer) Rules() []PhaseRule {
+	return c.rules
+}
+
+// DependsOn reports whether a change to the condition of conditionType can change the computed phase, i.e. some
+// rule reads it, by type or by prefix. Rules reading every condition, like those using AllPresentEqual,
+// depend on every condition type.
+func (c *PhaseComputer) DependsOn(conditionType string) bool {
+	if c.readsAll || c.types.Has(conditionType) {
+		return true
+	}
+
+	return slices.ContainsFunc(c.prefixes, func(prefix string) bool {
+		return strings.HasPrefix(conditionType, prefix)
+	})
+}
+
+func (c *PhaseComputer) addRuleDependencies(rule PhaseRule) {
+	switch r := rule.(type) {
+	case *phaseRuleSimple:
+		c.addMatcherDependencies(r.matcher)
+	case *phaseRuleNegated:
+		c.addRuleDependencies(r.base)
+	case *phaseRuleCombined:
+		for _, combined := range r.rules {
+			c.addRuleDependencies(combined)
+		}
+	default:
+		c.types.DestructiveUnion(rule.ConditionTypes())
+	}
+}
+
+func (c *PhaseComputer) addMatcherDependencies(matcher ConditionMatcher) {
+	switch m := matcher.(type) {
+	case *conditionPrefixUniformMatcher:
+		c.prefixes = append(c.prefixes, m.prefix)
+	case *conditionPrefixMatcher:
+		c.prefixes = append(c.prefixes, m.prefix)
+	case *conditionAllPresentMatcher:
+		c.readsAll = true
+	case *conditionMatcherAll:
+		c.addMatchersDependencies(m.matcherReferences)
+	case *conditionMatcherAny:
+		c.addMatchersDependencies(m.matcherReferences)
+	case *conditionMatcherAnyResolved:
+		c.addMatchersDependencies(m.matcherReferences)
+	case *conditionMatcherAtLeast:
+		c.addMatchersDependencies(m.matcherReferences)
+	case *conditionMatcherWeighted:
+		for _, weighted := range m.weighted {
+			c.addMatcherDependencies(weighted.Matcher)
+		}
+	case *conditionMatcherDominant:
+		c.addMatchersDependencies([]ConditionMatcher{m.dominant, m.rest})
+	default:
+		c.types.DestructiveUnion(matcher.ConditionTypes())
+	}
+}
+
+func (c *PhaseComputer) addMatchersDependencies(matchers []ConditionMatcher) {
+	for _, matcher := range matchers {
+		c.addMatcherDependencies(matcher)
+	}
+}
@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPhaseComputer_FirstSatisfiedWins(t *testing.T) {
+	computer := NewPhaseComputer(
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue), ConditionEquals("B", metav1.ConditionTrue))),
+		NewPhaseRule("Progressing", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue))),
+		NewPhaseRule("Failed", ConditionsAny(ConditionEquals("A", metav1.ConditionFalse))),
+	)
+
+	tests := []struct {
+		name      string
+		conds     *[]metav1.Condition
+		wantPhase string
+		wantMatch int
+	}{
+		{"first rule", &[]metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue)}, "Ready", 0},
+		{"earlier rule takes precedence", &[]metav1.Condition{cond("A", metav1.ConditionTrue)}, "Progressing", 1},
+		{"last rule", &[]metav1.Condition{cond("A", metav1.ConditionFalse)}, "Failed", 2},
+		{"no rule", &[]metav1.Condition{cond("A", metav1.ConditionUnknown)}, PhaseUnknown, -1},
+		{"nil conditions", nil, PhaseUnknown, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computer.Compute(tt.conds); got != tt.wantPhase {
+				t.Errorf("Compute() = %q, want %q", got, tt.wantPhase)
+			}
+			if got := computer.Match(tt.conds); got != tt.wantMatch {
+				t.Errorf("Match() = %d, want %d", got, tt.wantMatch)
+			}
+		})
+	}
+}
+
+func TestPhaseComputer_NoRules(t *testing.T) {
+	if got := NewPhaseComputer().Compute(&[]metav1.Condition{cond("A", metav1.ConditionTrue)}); got != PhaseUnknown {
+		t.Errorf("Compute() = %q, want %q", got, PhaseUnknown)
+	}
+}
+
+func TestPhaseComputer_DefaultPhase(t *testing.T) {
+	computer := NewPhaseComputerWithDefault("Pending",
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue))),
+	)
+
+	if got := computer.Compute(&[]metav1.Condition{cond("A", metav1.ConditionFalse)}); got != "Pending" {
+		t.Errorf("Compute() = %q, want the default %q", got, "Pending")
+	}
+	if got := computer.Compute(nil); got != "Pending" {
+		t.Errorf("Compute(nil) = %q, want the default %q", got, "Pending")
+	}
+	if got := computer.Compute(&[]metav1.Condition{cond("A", metav1.ConditionTrue)}); got != "Ready" {
+		t.Errorf("Compute() = %q, want %q", got, "Ready")
+	}
+	if got := NewPhaseComputer().DefaultPhase(); got != PhaseUnknown {
+		t.Errorf("DefaultPhase() = %q, want %q", got, PhaseUnknown)
+	}
+}
+
+func TestPhaseComputer_Priority(t *testing.T) {
+	degraded := NewPhaseRuleWithPriority("Degraded", 10, ConditionsAny(ConditionEquals("B", metav1.ConditionFalse)))
+	computer := NewPhaseComputer(
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue))),
+		NewPhaseRule("Failed", ConditionsAll(ConditionEquals("A", metav1.ConditionFalse))),
+		degraded,
+		NewPhaseRuleWithPriority("Deleting", 10, ConditionsAll(ConditionEquals("D", metav1.ConditionTrue))),
+	)
+
+	var order []string
+	for _, rule := range computer.Rules() {
+		order = append(order, rule.Phase())
+	}
+	if want := []string{"Degraded", "Deleting", "Ready", "Failed"}; !slices.Equal(order, want) {
+		t.Errorf("Rules() = %v, want %v", order, want)
+	}
+
+	conds := []metav1.Condition{
+		cond("A", metav1.ConditionTrue),
+		cond("B", metav1.ConditionFalse),
+		cond("D", metav1.ConditionTrue),
+	}
+	if got := computer.Compute(&conds); got != "Degraded" {
+		t.Errorf("Compute() = %q, want the higher priority %q over the rule listed first", got, "Degraded")
+	}
+	if got := computer.Match(&conds); got != 0 || computer.Rules()[got] != degraded {
+		t.Errorf("Match() = %d, want 0, the index in Rules()", got)
+	}
+}
+
+func TestPhaseComputer_DependsOn(t *testing.T) {
+	computer := NewPhaseComputer(
+		NewPhaseRule("Ready", ConditionsAll(
+			ConditionEquals("Available", metav1.ConditionTrue),
+			ConditionPrefixAll("Component/", metav1.ConditionTrue),
+		)),
+		Negate(NewPhaseRule("Synced", ConditionReasonIs("Synced", "Done")), "OutOfSync"),
+	)
+
+	for conditionType, want := range map[string]bool{
+		"Available":           true,
+		"Component/foo/Ready": true,
+		"Synced":              true,
+		"Progressing":         false,
+		"Component":           false,
+	} {
+		if got := computer.DependsOn(conditionType); got != want {
+			t.Errorf("DependsOn(%q) = %v, want %v", conditionType, got, want)
+		}
+	}
+
+	allPresent := NewPhaseComputer(NewPhaseRule("Settled", AllPresentEqual(metav1.ConditionTrue)))
+	if !allPresent.DependsOn("Progressing") {
+		t.Error("expected an AllPresentEqual rule to depend on every condition type")
+	}
+}
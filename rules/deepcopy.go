@@ -0,0 +1,103 @@
+package rules
+
+import "slices"
+
+// DeepCopy returns a copy of the rule sharing no slices with it, safe to hand to another manager.
+func (r *phaseRuleSimple) DeepCopy() PhaseRule {
+	copied := *r
+	copied.matcher = deepCopyMatcher(r.matcher)
+
+	return &copied
+}
+
+// DeepCopy returns a copy of the rule and its base rule.
+func (r *phaseRuleNegated) DeepCopy() PhaseRule {
+	return &phaseRuleNegated{
+		phase: r.phase,
+		base:  r.base.DeepCopy(),
+	}
+}
+
+// DeepCopy returns a copy of the rule and the rules it combines.
+func (r *phaseRuleCombined) DeepCopy() PhaseRule {
+	rules := make([]PhaseRule, len(r.rules))
+	for i, rule := range r.rules {
+		rules[i] = rule.DeepCopy()
+	}
+
+	return &phaseRuleCombined{
+		phase: r.phase,
+		rules: rules,
+	}
+}
+
+// deepCopyMatcher copies the matchers of this package along with their statuses, reasons and children.
+// Other matchers can't be copied and are shared as they are.
+func deepCopyMatcher(matcher ConditionMatcher) ConditionMatcher {
+	switch m := matcher.(type) {
+	case *conditionEqualsMatcher:
+		return &conditionEqualsMatcher{condition: m.condition, statuses: slices.Clone(m.statuses)}
+	case *conditionNotEqualsMatcher:
+		return &conditionNotEqualsMatcher{condition: m.condition, statuses: slices.Clone(m.statuses)}
+	case *conditionFreshlyEqualsMatcher:
+		return &conditionFreshlyEqualsMatcher{condition: m.condition, generation: m.generation, statuses: slices.Clone(m.statuses)}
+	case *conditionFreshMatcher:
+		copied := *m
+		return &copied
+	case *conditionWithinGenerationsMatcher:
+		return &conditionWithinGenerationsMatcher{condition: m.condition, generation: m.generation, generations: m.generations, statuses: slices.Clone(m.statuses)}
+	case *conditionReasonMatcher:
+		return &conditionReasonMatcher{condition: m.condition, reasons: slices.Clone(m.reasons)}
+	case *conditionReasonEqualsMatcher:
+		return &conditionReasonEqualsMatcher{condition: m.condition, status: m.status, reasons: slices.Clone(m.reasons)}
+	case *conditionMessageMatcher:
+		// a compiled regexp is safe to share
+		return &conditionMessageMatcher{condition: m.condition, statuses: slices.Clone(m.statuses), substr: m.substr, re: m.re}
+	case *conditionPresenceMatcher:
+		copied := *m
+		return &copied
+	case *conditionAgeMatcher:
+		copied := *m
+		return &copied
+	case *conditionAllPresentMatcher:
+		return &conditionAllPresentMatcher{statuses: slices.Clone(m.statuses)}
+	case *conditionPrefixUniformMatcher:
+		return &conditionPrefixUniformMatcher{prefix: m.prefix, statuses: slices.Clone(m.statuses)}
+	case *conditionPrefixMatcher:
+		return &conditionPrefixMatcher{prefix: m.prefix, statuses: slices.Clone(m.statuses), any: m.any}
+	case *conditionMatcherAll:
+		return &conditionMatcherAll{matcherReferences: deepCopyMatchers(m.matcherReferences)}
+	case *conditionMatcherAny:
+		return &conditionMatcherAny{matcherReferences: deepCopyMatchers(m.matcherReferences)}
+	case *conditionMatcherAnyResolved:
+		return &conditionMatcherAnyResolved{
+			conditionMatcherAny: conditionMatcherAny{matcherReferences: deepCopyMatchers(m.matcherReferences)},
+			resolution:          slices.Clone(m.resolution),
+		}
+	case *conditionMatcherAtLeast:
+		return &conditionMatcherAtLeast{n: m.n, matcherReferences: deepCopyMatchers(m.matcherReferences)}
+	case *conditionMatcherWeighted:
+		weighted := make([]WeightedMatcher, len(m.weighted))
+		for i, w := range m.weighted {
+			weighted[i] = WeightedMatcher{Matcher: deepCopyMatcher(w.Matcher), Weight: w.Weight}
+		}
+		return &conditionMatcherWeighted{threshold: m.threshold, weighted: weighted}
+	case *conditionMatcherDominant:
+		return &conditionMatcherDominant{dominant: deepCopyMatcher(m.dominant), rest: deepCopyMatcher(m.rest), effect: m.effect}
+	default:
+		return matcher
+	}
+}
+
+func deepCopyMatchers(matchers []ConditionMatcher) []ConditionMatcher {
+	if matchers == nil {
+		return nil
+	}
+
+	copied := make([]ConditionMatcher, len(matchers))
+	for i, matcher := range matchers {
+		copied[i] = deepCopyMatcher(matcher)
+	}
+
+	return copied
+}
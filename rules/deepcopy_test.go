@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeepCopy_Isolated(t *testing.T) {
+	rule := NewPhaseRule("Ready", ConditionsAll(
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionsAnyResolved(DefaultAnyResolution,
+			ConditionReasonIs("B", "Done"),
+			ConditionsDominant(ConditionEquals("C", metav1.ConditionFalse), ConditionNotEquals("D", metav1.ConditionFalse), DominantFails),
+		),
+	))
+
+	copied := rule.DeepCopy()
+
+	all := copied.(*phaseRuleSimple).matcher.(*conditionMatcherAll)
+	all.matcherReferences[0].(*conditionEqualsMatcher).statuses[0] = metav1.ConditionFalse
+	resolved := all.matcherReferences[1].(*conditionMatcherAnyResolved)
+	resolved.matcherReferences[0].(*conditionReasonMatcher).reasons[0] = "Failed"
+	resolved.resolution[0] = metav1.ConditionFalse
+	resolved.matcherReferences[1].(*conditionMatcherDominant).dominant.(*conditionEqualsMatcher).condition = "E"
+
+	conds := []metav1.Condition{
+		cond("A", metav1.ConditionTrue),
+		{Type: "B", Status: metav1.ConditionTrue, Reason: "Done"},
+		cond("C", metav1.ConditionTrue),
+		cond("D", metav1.ConditionTrue),
+	}
+
+	if !rule.Satisfies(&conds) {
+		t.Error("changing the copy changed the original rule")
+	}
+
+	original := rule.(*phaseRuleSimple).matcher.(*conditionMatcherAll).matcherReferences[1].(*conditionMatcherAnyResolved)
+	if original.resolution[0] != metav1.ConditionTrue || DefaultAnyResolution[0] != metav1.ConditionTrue {
+		t.Error("changing the copy's resolution changed the original one")
+	}
+	if got := original.matcherReferences[1].(*conditionMatcherDominant).dominant.ConditionTypes(); !got.Has("C") {
+		t.Errorf("original dominant matcher condition types = %v, want C", got)
+	}
+}
+
+func TestDeepCopy_Negated(t *testing.T) {
+	rule := Negate(NewPhaseRuleWithPriority("Ready", 3, ConditionEquals("A", metav1.ConditionTrue)), "NotReady")
+	copied := rule.DeepCopy()
+
+	conds := []metav1.Condition{cond("A", metav1.ConditionFalse)}
+	if copied.Phase() != "NotReady" || copied.Satisfies(&conds) != rule.Satisfies(&conds) {
+		t.Errorf("copy = %q satisfied %v, want NotReady satisfied %v", copied.Phase(), copied.Satisfies(&conds), rule.Satisfies(&conds))
+	}
+
+	copied.(*phaseRuleNegated).base.(*phaseRuleSimple).matcher.(*conditionEqualsMatcher).statuses[0] = metav1.ConditionFalse
+	if !rule.Satisfies(&conds) {
+		t.Error("changing the copy's base rule changed the original")
+	}
+}
@@ -0,0 +1,82 @@
+package rules
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// EvaluationResult is the outcome of evaluating an ordered list of phase rules against conditions.
+type EvaluationResult struct {
+	// Phase is the phase of the satisfied rule, the default phase if no rule is satisfied.
+	Phase string
+
+	// Rule is the first satisfied rule, nil if none is.
+	Rule PhaseRule
+
+	// Index is the position of Rule in the evaluation order, see PhaseComputer.Rules, -1 if no rule is satisfied.
+	Index int
+
+	// Diagnostics has an entry per evaluated rule in evaluation order: the unsatisfied rules before Rule, then Rule.
+	// The rules after Rule are not evaluated.
+	Diagnostics []RuleDiagnostic
+}
+
+// RuleDiagnostic tells how a single rule evaluated.
+type RuleDiagnostic struct {
+	Phase     string
+	Satisfied bool
+
+	// Unmet are the condition types that need to change for the rule to be satisfied, sorted.
+	// Empty for a satisfied rule.
+	Unmet []string
+}
+
+// Evaluate evaluates the rules against the conditions in a single pass, first satisfied rule wins.
+func Evaluate(rules []PhaseRule, conditions *[]metav1.Condition) EvaluationResult {
+	return NewPhaseComputer(rules...).Evaluate(conditions)
+}
+
+// Evaluate returns the phase, the satisfied rule and a diagnostic per evaluated rule in a single pass over the rules.
+func (c *PhaseComputer) Evaluate(conditions *[]metav1.Condition) EvaluationResult {
+	result := EvaluationResult{
+		Phase: c.defaultPhase,
+		Index: -1,
+	}
+
+	for i, rule := range c.rules {
+		diagnostic := diagnoseRule(rule, conditions)
+		result.Diagnostics = append(result.Diagnostics, diagnostic)
+
+		if diagnostic.Satisfied {
+			result.Phase, result.Rule, result.Index = rule.Phase(), rule, i
+			return result
+		}
+	}
+
+	return result
+}
+
+// ComputePhaseDetailed is ComputePhase also returning the rule and how it evaluated: the rule's phase and the rule
+// itself if it is satisfied, PhaseUnknown and nil if not, with the rule's diagnostic. Evaluate does the same for a
+// list of rules.
+func ComputePhaseDetailed(rule PhaseRule, conditions *[]metav1.Condition) EvaluationResult {
+	return Evaluate([]PhaseRule{rule}, conditions)
+}
+
+// diagnoseRule tells whether rule is satisfied by the conditions and, if not, what needs to change.
+func diagnoseRule(rule PhaseRule, conditions *[]metav1.Condition) RuleDiagnostic {
+	if rule.Satisfies(conditions) {
+		return RuleDiagnostic{Phase: rule.Phase(), Satisfied: true}
+	}
+
+	var stateConditions []metav1.Condition
+	if conditions != nil {
+		stateConditions = *conditions
+	}
+
+	return RuleDiagnostic{
+		Phase: rule.Phase(),
+		Unmet: sets.SortedSlice(unmetConditionTypes(rule, stateConditions)),
+	}
+}
@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEvaluate_SecondRuleSatisfied(t *testing.T) {
+	rules := milestoneRules()
+	conds := []metav1.Condition{
+		cond("Scheduled", metav1.ConditionTrue),
+		cond("Provisioned", metav1.ConditionFalse),
+	}
+
+	result := Evaluate(rules, &conds)
+
+	if result.Phase != "Pending" {
+		t.Errorf("Phase = %q, want %q", result.Phase, "Pending")
+	}
+	if result.Index != 1 || result.Rule != rules[1] {
+		t.Errorf("Index = %d, Rule = %v, want the second rule", result.Index, result.Rule)
+	}
+	if want := NewPhaseComputer(rules...).Compute(&conds); result.Phase != want {
+		t.Errorf("Phase = %q, disagrees with Compute() = %q", result.Phase, want)
+	}
+
+	if len(result.Diagnostics) != 2 {
+		t.Fatalf("got %d diagnostics, want 2", len(result.Diagnostics))
+	}
+
+	ready := result.Diagnostics[0]
+	if ready.Phase != "Ready" || ready.Satisfied {
+		t.Errorf("diagnostics[0] = %+v, want unsatisfied Ready", ready)
+	}
+	if want := []string{"Healthy", "Provisioned"}; !slices.Equal(ready.Unmet, want) {
+		t.Errorf("diagnostics[0].Unmet = %v, want %v", ready.Unmet, want)
+	}
+
+	pending := result.Diagnostics[1]
+	if pending.Phase != "Pending" || !pending.Satisfied || len(pending.Unmet) != 0 {
+		t.Errorf("diagnostics[1] = %+v, want satisfied Pending", pending)
+	}
+}
+
+func TestEvaluate_NoRuleSatisfied(t *testing.T) {
+	rules := milestoneRules()
+
+	result := NewPhaseComputerWithDefault("Waiting", rules...).Evaluate(nil)
+
+	if result.Phase != "Waiting" || result.Rule != nil || result.Index != -1 {
+		t.Errorf("result = %q/%v/%d, want the default phase, no rule, -1", result.Phase, result.Rule, result.Index)
+	}
+	if len(result.Diagnostics) != len(rules) {
+		t.Fatalf("got %d diagnostics, want one per rule", len(result.Diagnostics))
+	}
+	if want := []string{"Scheduled"}; !slices.Equal(result.Diagnostics[1].Unmet, want) {
+		t.Errorf("diagnostics[1].Unmet = %v, want %v", result.Diagnostics[1].Unmet, want)
+	}
+}
+
+func TestComputePhaseDetailed(t *testing.T) {
+	ready := NewPhaseRule("Ready", ConditionsAll(
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("B", metav1.ConditionTrue),
+	))
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse)}
+
+	result := ComputePhaseDetailed(ready, &conds)
+	if result.Phase != PhaseUnknown || result.Rule != nil {
+		t.Errorf("result = %q/%v, want Unknown and no rule", result.Phase, result.Rule)
+	}
+	if len(result.Diagnostics) != 1 || !slices.Equal(result.Diagnostics[0].Unmet, []string{"B"}) {
+		t.Errorf("Diagnostics = %+v, want Ready unmet on B", result.Diagnostics)
+	}
+
+	notReady := Negate(ready, "NotReady")
+	if result := ComputePhaseDetailed(notReady, &conds); result.Phase != "NotReady" || result.Rule != notReady {
+		t.Errorf("negated result = %q/%v, want NotReady and the rule", result.Phase, result.Rule)
+	}
+
+	if result := ComputePhaseDetailed(ready, nil); result.Phase != PhaseUnknown || result.Rule != nil {
+		t.Errorf("result for nil conditions = %q/%v, want Unknown and no rule", result.Phase, result.Rule)
+	}
+}
+
+func TestComputePhaseDetailed_CombineRules(t *testing.T) {
+	serving := NewPhaseRule("Serving", ConditionEquals("A", metav1.ConditionTrue))
+	idle := NewPhaseRule("Idle", ConditionEquals("B", metav1.ConditionTrue))
+	ready := CombineRules("Ready", serving, idle)
+
+	conds := []metav1.Condition{cond("A", metav1.ConditionFalse), cond("B", metav1.ConditionTrue)}
+
+	// the combined rule reports the phase, not the combined rule that is satisfied
+	result := ComputePhaseDetailed(ready, &conds)
+	if result.Phase != "Ready" || result.Rule != ready || result.Rule.Phase() != result.Phase {
+		t.Errorf("result = %q/%v, want Ready reported by the combined rule", result.Phase, result.Rule)
+	}
+	if result.Phase != ready.ComputePhase(&conds) {
+		t.Errorf("Phase = %q, disagrees with ComputePhase() = %q", result.Phase, ready.ComputePhase(&conds))
+	}
+	if len(result.Diagnostics) != 1 || !result.Diagnostics[0].Satisfied {
+		t.Errorf("Diagnostics = %+v, want Ready satisfied", result.Diagnostics)
+	}
+}
@@ -0,0 +1,212 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// Explain returns a trace of how the rule evaluates conditions, one matcher per line with its result and
+// the state of the condition it looks at, e.g.
+//
+//	phase Ready: not satisfied
+//	  NotMatched: all of
+//	    Matched: A is True (A is True)
+//	    NotMatched: B is True (B is missing)
+//	    NotMatched: C is True (C is False: Broken)
+//
+// ConditionsAny lists every alternative tried. Missing conditions evaluate as Unknown, like in Satisfies.
+func (r *phaseRuleSimple) Explain(conditions *[]metav1.Condition) string {
+	var b strings.Builder
+
+	explainRule(&b, r, conditions, 0)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Explain returns the trace of the base rule under a line telling whether the negation is satisfied.
+func (r *phaseRuleNegated) Explain(conditions *[]metav1.Condition) string {
+	var b strings.Builder
+
+	explainRule(&b, r, conditions, 0)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Explain returns the traces of the combined rules under a line telling whether any of them is satisfied.
+func (r *phaseRuleCombined) Explain(conditions *[]metav1.Condition) string {
+	var b strings.Builder
+
+	explainRule(&b, r, conditions, 0)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func explainRule(b *strings.Builder, rule PhaseRule, conditions *[]metav1.Condition, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	satisfied := "not satisfied"
+	if rule.Satisfies(conditions) {
+		satisfied = "satisfied"
+	}
+
+	if conditions == nil {
+		fmt.Fprintf(b, "%sphase %s: %s, no conditions\n", indent, rule.Phase(), satisfied)
+		return
+	}
+
+	switch r := rule.(type) {
+	case *phaseRuleSimple:
+		fmt.Fprintf(b, "%sphase %s: %s\n", indent, r.Phase(), satisfied)
+
+		stateConditions, missing := withUnknownConditions(*conditions, r.matcher.ConditionTypes())
+		explainMatcher(b, r.matcher, *conditions, &stateConditions, missing, depth+1)
+	case *phaseRuleNegated:
+		fmt.Fprintf(b, "%sphase %s: %s, negating\n", indent, r.Phase(), satisfied)
+		explainRule(b, r.base, conditions, depth+1)
+	case *phaseRuleCombined:
+		fmt.Fprintf(b, "%sphase %s: %s, any of\n", indent, r.Phase(), satisfied)
+
+		for _, combined := range r.rules {
+			explainRule(b, combined, conditions, depth+1)
+		}
+	default:
+		fmt.Fprintf(b, "%sphase %s: %s\n", indent, rule.Phase(), satisfied)
+	}
+}
+
+// explainMatcher writes a line for matcher, evaluated against stateConditions, and its children below it.
+// observed are the conditions as given, to tell missing conditions from Unknown ones, missing the types
+// stateConditions stands in for.
+func explainMatcher(b *strings.Builder, matcher ConditionMatcher, observed []metav1.Condition, stateConditions *[]metav1.Condition, missing sets.Set[string], depth int) {
+	fmt.Fprintf(b, "%s%s: %s", strings.Repeat("  ", depth), matches(matcher, stateConditions, missing), describeMatcher(matcher))
+
+	var children []ConditionMatcher
+
+	switch m := matcher.(type) {
+	case *conditionMatcherAll:
+		children = m.matcherReferences
+	case *conditionMatcherAny:
+		children = m.matcherReferences
+	case *conditionMatcherAnyResolved:
+		children = m.matcherReferences
+	case *conditionMatcherAtLeast:
+		children = m.matcherReferences
+	case *conditionMatcherWeighted:
+		for _, weighted := range m.weighted {
+			children = append(children, weighted.Matcher)
+		}
+	case *conditionMatcherDominant:
+		children = []ConditionMatcher{m.dominant, m.rest}
+	default:
+		if types := matcher.ConditionTypes(); types.Len() == 1 {
+			for conditionType := range types {
+				fmt.Fprintf(b, " (%s)", describeCondition(observed, conditionType))
+			}
+		}
+	}
+
+	b.WriteString("\n")
+
+	for _, child := range children {
+		explainMatcher(b, child, observed, stateConditions, missing, depth+1)
+	}
+}
+
+// describeMatcher describes what matcher expects, without its children.
+func describeMatcher(matcher ConditionMatcher) string {
+	switch m := matcher.(type) {
+	case *conditionEqualsMatcher:
+		return fmt.Sprintf("%s is %s", m.condition, joinStatuses(m.statuses))
+	case *conditionNotEqualsMatcher:
+		return fmt.Sprintf("%s is not %s", m.condition, joinStatuses(m.statuses))
+	case *conditionFreshlyEqualsMatcher:
+		return fmt.Sprintf("%s is %s at generation %d", m.condition, joinStatuses(m.statuses), m.generation)
+	case *conditionFreshMatcher:
+		return fmt.Sprintf("%s observed at generation %d or later", m.condition, m.generation)
+	case *conditionWithinGenerationsMatcher:
+		return fmt.Sprintf("%s is %s within %d generations of generation %d", m.condition, joinStatuses(m.statuses), m.generations, m.generation)
+	case *conditionReasonMatcher:
+		return fmt.Sprintf("%s has reason %s", m.condition, strings.Join(m.reasons, " or "))
+	case *conditionReasonEqualsMatcher:
+		return fmt.Sprintf("%s is %s with reason %s", m.condition, m.status, strings.Join(m.reasons, " or "))
+	case *conditionAgeMatcher:
+		if m.younger {
+			return fmt.Sprintf("%s transitioned less than %s ago", m.condition, m.duration)
+		}
+		return fmt.Sprintf("%s transitioned at least %s ago", m.condition, m.duration)
+	case *conditionMessageMatcher:
+		description := fmt.Sprintf("%s message contains %q", m.condition, m.substr)
+		if m.re != nil {
+			description = fmt.Sprintf("%s message matches %q", m.condition, m.re)
+		}
+		if len(m.statuses) > 0 {
+			description = fmt.Sprintf("%s is %s and its%s", m.condition, joinStatuses(m.statuses), strings.TrimPrefix(description, m.condition))
+		}
+		return description
+	case *conditionPresenceMatcher:
+		if m.missing {
+			return m.condition + " is missing"
+		}
+		return m.condition + " is present"
+	case *conditionAllPresentMatcher:
+		return "all present conditions are " + joinStatuses(m.statuses)
+	case *conditionPrefixUniformMatcher:
+		if len(m.statuses) == 0 {
+			return fmt.Sprintf("conditions prefixed %q share a status", m.prefix)
+		}
+		return fmt.Sprintf("conditions prefixed %q are all %s", m.prefix, joinStatuses(m.statuses))
+	case *conditionPrefixMatcher:
+		if m.any {
+			return fmt.Sprintf("any condition prefixed %q is %s", m.prefix, joinStatuses(m.statuses))
+		}
+		return fmt.Sprintf("conditions prefixed %q are all %s", m.prefix, joinStatuses(m.statuses))
+	case *conditionMatcherAll:
+		return "all of"
+	case *conditionMatcherAny, *conditionMatcherAnyResolved:
+		return "any of"
+	case *conditionMatcherAtLeast:
+		return fmt.Sprintf("at least %d of", m.n)
+	case *conditionMatcherWeighted:
+		weights := make([]string, len(m.weighted))
+		for i, weighted := range m.weighted {
+			weights[i] = strconv.FormatFloat(weighted.Weight, 'g', -1, 64)
+		}
+		return fmt.Sprintf("weights reaching %s of %s", strconv.FormatFloat(m.threshold, 'g', -1, 64), strings.Join(weights, ", "))
+	case *conditionMatcherDominant:
+		if m.effect == DominantSatisfies {
+			return "the first, or else the second"
+		}
+		return "the second, unless the first matches"
+	default:
+		return fmt.Sprintf("%T", matcher)
+	}
+}
+
+// describeCondition describes the state of a condition type, e.g. "A is False: Broken" or "A is missing".
+func describeCondition(conditions []metav1.Condition, conditionType string) string {
+	condition := meta.FindStatusCondition(conditions, conditionType)
+	if condition == nil {
+		return conditionType + " is missing"
+	}
+
+	if condition.Reason == "" {
+		return fmt.Sprintf("%s is %s", conditionType, condition.Status)
+	}
+
+	return fmt.Sprintf("%s is %s: %s", conditionType, condition.Status, condition.Reason)
+}
+
+func joinStatuses(statuses []metav1.ConditionStatus) string {
+	joined := make([]string, len(statuses))
+	for i, status := range statuses {
+		joined[i] = string(status)
+	}
+
+	return strings.Join(joined, " or ")
+}
@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"regexp"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExplain_All(t *testing.T) {
+	rule := NewPhaseRule("Ready", ConditionsAll(
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("B", metav1.ConditionTrue),
+		ConditionEquals("C", metav1.ConditionTrue),
+	))
+	conds := []metav1.Condition{
+		cond("A", metav1.ConditionTrue),
+		{Type: "C", Status: metav1.ConditionFalse, Reason: "Broken"},
+	}
+
+	want := `phase Ready: not satisfied
+  NotMatched: all of
+    Matched: A is True (A is True)
+    NotMatched: B is True (B is missing)
+    NotMatched: C is True (C is False: Broken)`
+
+	if got := rule.Explain(&conds); got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExplain_AnyListsAlternatives(t *testing.T) {
+	rule := NewPhaseRule("Failed", ConditionsAny(
+		ConditionEquals("A", metav1.ConditionFalse),
+		ConditionReasonIs("B", "Crashed", "OOMKilled"),
+	))
+	conds := []metav1.Condition{
+		cond("A", metav1.ConditionTrue),
+		{Type: "B", Status: metav1.ConditionFalse, Reason: "OOMKilled"},
+	}
+
+	want := `phase Failed: satisfied
+  Matched: any of
+    NotMatched: A is False (A is True)
+    Matched: B has reason Crashed or OOMKilled (B is False: OOMKilled)`
+
+	if got := rule.Explain(&conds); got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExplain_Negate(t *testing.T) {
+	ready := NewPhaseRule("Ready", ConditionEquals("A", metav1.ConditionTrue))
+	conds := []metav1.Condition{cond("A", metav1.ConditionFalse)}
+
+	want := `phase NotReady: satisfied, negating
+  phase Ready: not satisfied
+    NotMatched: A is True (A is False)`
+
+	if got := Negate(ready, "NotReady").Explain(&conds); got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExplain_CombineRules(t *testing.T) {
+	ready := CombineRules("Ready",
+		NewPhaseRule("Serving", ConditionEquals("A", metav1.ConditionTrue)),
+		NewPhaseRule("Idle", ConditionEquals("B", metav1.ConditionTrue)),
+	)
+	conds := []metav1.Condition{cond("A", metav1.ConditionFalse), cond("B", metav1.ConditionTrue)}
+
+	want := `phase Ready: satisfied, any of
+  phase Serving: not satisfied
+    NotMatched: A is True (A is False)
+  phase Idle: satisfied
+    Matched: B is True (B is True)`
+
+	if got := ready.Explain(&conds); got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExplain_ConditionMessage(t *testing.T) {
+	rule := NewPhaseRule("QuotaExceeded", ConditionsAny(
+		ConditionMessageContains("Ready", "quota exceeded", metav1.ConditionFalse),
+		ConditionMessageMatches("Ready", regexp.MustCompile(`retry \d+`)),
+	))
+	conds := []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Failed", Message: "retry 2"}}
+
+	want := `phase QuotaExceeded: satisfied
+  Matched: any of
+    NotMatched: Ready is False and its message contains "quota exceeded" (Ready is False: Failed)
+    Matched: Ready message matches "retry \\d+" (Ready is False: Failed)`
+
+	if got := rule.Explain(&conds); got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExplain_NilConditions(t *testing.T) {
+	rule := NewPhaseRule("Ready", ConditionEquals("A", metav1.ConditionTrue))
+
+	if got, want := rule.Explain(nil), "phase Ready: not satisfied, no conditions"; got != want {
+		t.Errorf("Explain() = %q, want %q", got, want)
+	}
+}
+
+func TestExplain_Presence(t *testing.T) {
+	rule := NewPhaseRule("Initializing", ConditionsAll(ConditionMissing("A"), ConditionMissing("B")))
+	conds := []metav1.Condition{cond("A", metav1.ConditionUnknown)}
+
+	want := `phase Initializing: not satisfied
+  NotMatched: all of
+    NotMatched: A is missing (A is Unknown)
+    Matched: B is missing (B is missing)`
+
+	if got := rule.Explain(&conds); got != want {
+		t.Errorf("Explain() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,27 @@
+package rules
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HealthScore returns a score between 0 and 100: the summed weights of the weighted condition types that are True,
+// over the total weight. Missing conditions count as not True. Weights are expected to be non-negative,
+// a zero total weight scores 0.
+func HealthScore(conditions *[]metav1.Condition, weights map[string]float64) float64 {
+	var total, healthy float64
+
+	for conditionType, weight := range weights {
+		total += weight
+
+		if conditions != nil && meta.IsStatusConditionTrue(*conditions, conditionType) {
+			healthy += weight
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return healthy / total * 100
+}
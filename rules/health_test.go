@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHealthScore(t *testing.T) {
+	weights := map[string]float64{"A": 3, "B": 1}
+
+	tests := []struct {
+		name  string
+		conds *[]metav1.Condition
+		want  float64
+	}{
+		{"all healthy", &[]metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue)}, 100},
+		{"all unhealthy", &[]metav1.Condition{cond("A", metav1.ConditionFalse), cond("B", metav1.ConditionUnknown)}, 0},
+		{"partial", &[]metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse)}, 75},
+		{"missing counts as unhealthy", &[]metav1.Condition{cond("B", metav1.ConditionTrue)}, 25},
+		{"unweighted conditions ignored", &[]metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue), cond("C", metav1.ConditionFalse)}, 100},
+		{"nil conditions", nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HealthScore(tt.conds, weights); got != tt.want {
+				t.Errorf("HealthScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthScore_NoWeights(t *testing.T) {
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue)}
+	if got := HealthScore(&conds, nil); got != 0 {
+		t.Errorf("HealthScore() = %v, want 0 without weights", got)
+	}
+}
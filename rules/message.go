@@ -0,0 +1,72 @@
+package rules
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+type conditionMessageMatcher struct {
+	condition string
+	// statuses the condition must also have, any if empty
+	statuses []metav1.ConditionStatus
+
+	// exactly one of substr or re is used
+	substr string
+	re     *regexp.Regexp
+}
+
+var _ ConditionMatcher = (*conditionMessageMatcher)(nil)
+
+func (m *conditionMessageMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+// matchesMissing is MatcherNotMatched for the Unknown conditions a phase rule stands in for missing ones, they have no
+// message.
+func (m *conditionMessageMatcher) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		if missing.Has(condition.Type) {
+			return false
+		}
+
+		if len(m.statuses) > 0 && !slices.Contains(m.statuses, condition.Status) {
+			return false
+		}
+
+		if m.re != nil {
+			return m.re.MatchString(condition.Message)
+		}
+
+		return strings.Contains(condition.Message, m.substr)
+	})
+}
+
+func (m *conditionMessageMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+// ConditionMessageContains returns a matcher for a condition type whose message contains substr, e.g.
+// ConditionMessageContains("Ready", "quota exceeded", metav1.ConditionFalse) for controllers encoding error classes in
+// the message. If statuses are given, the condition must also have one of them.
+func ConditionMessageContains(condition, substr string, statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionMessageMatcher{
+		condition: condition,
+		statuses:  statuses,
+		substr:    substr,
+	}
+}
+
+// ConditionMessageMatches returns a matcher for a condition type whose message matches re, e.g. to read a retry count
+// out of it. If statuses are given, the condition must also have one of them.
+func ConditionMessageMatches(condition string, re *regexp.Regexp, statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionMessageMatcher{
+		condition: condition,
+		statuses:  statuses,
+		re:        re,
+	}
+}
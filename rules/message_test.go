@@ -0,0 +1,52 @@
+package rules
+
+import (
+	"regexp"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionMessage(t *testing.T) {
+	quota := ConditionMessageContains("Ready", "quota exceeded")
+	quotaFalse := ConditionMessageContains("Ready", "quota exceeded", metav1.ConditionFalse)
+	retries := ConditionMessageMatches("Ready", regexp.MustCompile(`retry [3-9]\b`))
+
+	tests := []struct {
+		name                            string
+		conds                           *[]metav1.Condition
+		wantQuota, wantFalse, wantRetry MatchResult
+	}{
+		{"quota False", &[]metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Message: "retry 1: quota exceeded"}}, MatcherMatched, MatcherMatched, MatcherNotMatched},
+		{"quota Unknown", &[]metav1.Condition{{Type: "Ready", Status: metav1.ConditionUnknown, Message: "quota exceeded, retry 4"}}, MatcherMatched, MatcherNotMatched, MatcherMatched},
+		{"other message", &[]metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Message: "retry 12: timeout"}}, MatcherNotMatched, MatcherNotMatched, MatcherNotMatched},
+		{"missing", &[]metav1.Condition{{Type: "Synced", Status: metav1.ConditionFalse, Message: "quota exceeded"}}, MatcherUnknown, MatcherUnknown, MatcherUnknown},
+		{"nil conditions", nil, MatcherUnknown, MatcherUnknown, MatcherUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quota.Matches(tt.conds); got != tt.wantQuota {
+				t.Errorf("ConditionMessageContains().Matches() = %v, want %v", got, tt.wantQuota)
+			}
+			if got := quotaFalse.Matches(tt.conds); got != tt.wantFalse {
+				t.Errorf("ConditionMessageContains() with a status Matches() = %v, want %v", got, tt.wantFalse)
+			}
+			if got := retries.Matches(tt.conds); got != tt.wantRetry {
+				t.Errorf("ConditionMessageMatches().Matches() = %v, want %v", got, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestConditionMessage_MissingInRule(t *testing.T) {
+	// any message contains "", but a missing condition has none
+	rule := NewPhaseRule("Reported", ConditionMessageContains("Ready", ""))
+
+	if rule.Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected a missing condition not to match any message")
+	}
+	if !rule.Satisfies(&[]metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue}}) {
+		t.Error("expected a present condition to contain the empty string")
+	}
+}
@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"cmp"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// NextPhase returns the phase to reach next and the condition types that still need to change to reach it,
+// e.g. for a progress UI showing the next milestone. Rules are evaluated by priority, then in order, first match
+// wins, so the next phase is that of the rule evaluated right before the currently satisfied one; with no rule
+// satisfied it is the phase of the last rule evaluated. It returns "" and nil if the first rule is already satisfied or there are no rules.
+//
+// The condition types are those of the unmatched matchers of the next rule, of the closest alternative for
+// ConditionsAny, sorted. Missing conditions are evaluated as Unknown, like a rule does.
+func NextPhase(rules []PhaseRule, conditions *[]metav1.Condition) (string, []string) {
+	computer := NewPhaseComputer(rules...)
+	rules = computer.Rules()
+
+	current := computer.Match(conditions)
+	if current < 0 {
+		current = len(rules)
+	}
+
+	if current == 0 {
+		return "", nil
+	}
+
+	next := rules[current-1]
+
+	var stateConditions []metav1.Condition
+	if conditions != nil {
+		stateConditions = *conditions
+	}
+
+	return next.Phase(), sets.SortedSlice(unmetConditionTypes(next, stateConditions))
+}
+
+// unmetConditionTypes returns the condition types rule needs changed to be satisfied.
+func unmetConditionTypes(rule PhaseRule, conditions []metav1.Condition) sets.Set[string] {
+	switch r := rule.(type) {
+	case *phaseRuleSimple:
+		stateConditions, missing := withUnknownConditions(conditions, r.matcher.ConditionTypes())
+		return unmetMatcherTypes(r.matcher, &stateConditions, missing)
+	case *phaseRuleNegated:
+		// negating is about changing any of the base's conditions, there are no specific ones
+		return r.ConditionTypes()
+	case *phaseRuleCombined:
+		// the combined rule needing the fewest changes
+		var closest sets.Set[string]
+
+		for _, combined := range r.rules {
+			if unmet := unmetConditionTypes(combined, conditions); closest == nil || unmet.Len() < closest.Len() {
+				closest = unmet
+			}
+		}
+
+		if closest == nil {
+			return sets.New[string]()
+		}
+
+		return closest
+	default:
+		return rule.ConditionTypes()
+	}
+}
+
+// unmetMatcherTypes returns the condition types of the parts of matcher that don't match, the conditions of the types
+// in missing stand in for missing ones.
+func unmetMatcherTypes(matcher ConditionMatcher, conditions *[]metav1.Condition, missing sets.Set[string]) sets.Set[string] {
+	if matches(matcher, conditions, missing) == MatcherMatched {
+		return sets.New[string]()
+	}
+
+	switch m := matcher.(type) {
+	case *conditionMatcherAll:
+		unmet := sets.New[string]()
+
+		for _, child := range m.matcherReferences {
+			unmet.DestructiveUnion(unmetMatcherTypes(child, conditions, missing))
+		}
+
+		return unmet
+	case *conditionMatcherAny:
+		return closestAlternative(m.matcherReferences, conditions, missing)
+	case *conditionMatcherAnyResolved:
+		return closestAlternative(m.matcherReferences, conditions, missing)
+	case *conditionMatcherAtLeast:
+		return closestQuorum(m.n, m.matcherReferences, conditions, missing)
+	case *conditionMatcherWeighted:
+		return closestWeight(m.threshold, m.weighted, conditions, missing)
+	case *conditionAllPresentMatcher:
+		// the present conditions not yet in an allowed status, none if there are no conditions at all
+		unmet := sets.New[string]()
+
+		for _, condition := range *conditions {
+			if !missing.Has(condition.Type) && !slices.Contains(m.statuses, condition.Status) {
+				unmet.Insert(condition.Type)
+			}
+		}
+
+		return unmet
+	default:
+		return matcher.ConditionTypes()
+	}
+}
+
+// closestAlternative returns the unmet condition types of the alternative needing the fewest changes.
+func closestAlternative(alternatives []ConditionMatcher, conditions *[]metav1.Condition, missing sets.Set[string]) sets.Set[string] {
+	var closest sets.Set[string]
+
+	for _, alternative := range alternatives {
+		if unmet := unmetMatcherTypes(alternative, conditions, missing); closest == nil || unmet.Len() < closest.Len() {
+			closest = unmet
+		}
+	}
+
+	if closest == nil {
+		return sets.New[string]()
+	}
+
+	return closest
+}
+
+// closestQuorum returns the unmet condition types of the unmatched matchers needing the fewest changes
+// to bring the number of matching matchers to n.
+func closestQuorum(n int, matchers []ConditionMatcher, conditions *[]metav1.Condition, missing sets.Set[string]) sets.Set[string] {
+	var unmatched []sets.Set[string]
+
+	for _, matcher := range matchers {
+		if matches(matcher, conditions, missing) == MatcherMatched {
+			n--
+			continue
+		}
+
+		unmatched = append(unmatched, unmetMatcherTypes(matcher, conditions, missing))
+	}
+
+	slices.SortStableFunc(unmatched, func(a, b sets.Set[string]) int {
+		return a.Len() - b.Len()
+	})
+
+	unmet := sets.New[string]()
+
+	for _, types := range unmatched[:min(max(n, 0), len(unmatched))] {
+		unmet.DestructiveUnion(types)
+	}
+
+	return unmet
+}
+
+// closestWeight returns the unmet condition types of the unmatched matchers bringing the matched weight to threshold,
+// the heaviest first.
+func closestWeight(threshold float64, weighted []WeightedMatcher, conditions *[]metav1.Condition, missing sets.Set[string]) sets.Set[string] {
+	var unmatched []WeightedMatcher
+
+	for _, w := range weighted {
+		if matches(w.Matcher, conditions, missing) == MatcherMatched {
+			threshold -= w.Weight
+			continue
+		}
+
+		unmatched = append(unmatched, w)
+	}
+
+	slices.SortStableFunc(unmatched, func(a, b WeightedMatcher) int {
+		return cmp.Compare(b.Weight, a.Weight)
+	})
+
+	unmet := sets.New[string]()
+
+	for _, w := range unmatched {
+		if threshold <= 0 {
+			break
+		}
+
+		unmet.DestructiveUnion(unmetMatcherTypes(w.Matcher, conditions, missing))
+		threshold -= w.Weight
+	}
+
+	return unmet
+}
@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func milestoneRules() []PhaseRule {
+	return []PhaseRule{
+		NewPhaseRule("Ready", ConditionsAll(
+			ConditionEquals("Scheduled", metav1.ConditionTrue),
+			ConditionEquals("Provisioned", metav1.ConditionTrue),
+			ConditionEquals("Healthy", metav1.ConditionTrue),
+		)),
+		NewPhaseRule("Pending", ConditionsAll(
+			ConditionEquals("Scheduled", metav1.ConditionTrue),
+		)),
+	}
+}
+
+func TestNextPhase_FromPending(t *testing.T) {
+	conds := []metav1.Condition{
+		cond("Scheduled", metav1.ConditionTrue),
+		cond("Provisioned", metav1.ConditionFalse),
+	}
+
+	phase, needed := NextPhase(milestoneRules(), &conds)
+	if phase != "Ready" {
+		t.Errorf("NextPhase() phase = %q, want %q", phase, "Ready")
+	}
+	if want := []string{"Healthy", "Provisioned"}; !slices.Equal(needed, want) {
+		t.Errorf("NextPhase() needed = %v, want %v", needed, want)
+	}
+}
+
+func TestNextPhase_AlreadyFirst(t *testing.T) {
+	conds := []metav1.Condition{
+		cond("Scheduled", metav1.ConditionTrue),
+		cond("Provisioned", metav1.ConditionTrue),
+		cond("Healthy", metav1.ConditionTrue),
+	}
+
+	if phase, needed := NextPhase(milestoneRules(), &conds); phase != "" || needed != nil {
+		t.Errorf("NextPhase() = (%q, %v), want no next phase", phase, needed)
+	}
+}
+
+func TestNextPhase_NoneSatisfied(t *testing.T) {
+	phase, needed := NextPhase(milestoneRules(), &[]metav1.Condition{})
+	if phase != "Pending" || !slices.Equal(needed, []string{"Scheduled"}) {
+		t.Errorf("NextPhase() = (%q, %v), want (Pending, [Scheduled])", phase, needed)
+	}
+}
+
+func TestNextPhase_ClosestAlternative(t *testing.T) {
+	phaseRules := []PhaseRule{
+		NewPhaseRule("Ready", ConditionsAny(
+			ConditionsAll(ConditionEquals("A", metav1.ConditionTrue), ConditionEquals("B", metav1.ConditionTrue)),
+			ConditionsAll(ConditionEquals("C", metav1.ConditionTrue), ConditionEquals("D", metav1.ConditionTrue)),
+		)),
+	}
+	conds := []metav1.Condition{cond("C", metav1.ConditionTrue)}
+
+	if phase, needed := NextPhase(phaseRules, &conds); phase != "Ready" || !slices.Equal(needed, []string{"D"}) {
+		t.Errorf("NextPhase() = (%q, %v), want (Ready, [D])", phase, needed)
+	}
+}
+
+func TestNextPhase_Quorum(t *testing.T) {
+	phaseRules := []PhaseRule{
+		NewPhaseRule("PartiallyReady", ConditionsAtLeast(2,
+			ConditionEquals("A", metav1.ConditionTrue),
+			ConditionsAll(ConditionEquals("B", metav1.ConditionTrue), ConditionEquals("C", metav1.ConditionTrue)),
+			ConditionEquals("D", metav1.ConditionTrue),
+		)),
+	}
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue)}
+
+	if phase, needed := NextPhase(phaseRules, &conds); phase != "PartiallyReady" || !slices.Equal(needed, []string{"D"}) {
+		t.Errorf("NextPhase() = (%q, %v), want (PartiallyReady, [D])", phase, needed)
+	}
+}
+
+func TestNextPhase_Weighted(t *testing.T) {
+	rules := []PhaseRule{
+		NewPhaseRule("Healthy", ConditionsWeighted(4,
+			WeightedMatcher{Matcher: ConditionEquals("Database", metav1.ConditionTrue), Weight: 3},
+			WeightedMatcher{Matcher: ConditionEquals("Cache", metav1.ConditionTrue), Weight: 1},
+			WeightedMatcher{Matcher: ConditionEquals("Search", metav1.ConditionTrue), Weight: 2},
+		)),
+		NewPhaseRule("Degraded", ConditionEquals("Cache", metav1.ConditionTrue)),
+	}
+	conds := []metav1.Condition{
+		cond("Database", metav1.ConditionFalse),
+		cond("Cache", metav1.ConditionTrue),
+		cond("Search", metav1.ConditionFalse),
+	}
+
+	// 1 of 4 matched, the heaviest unmatched, Database, makes up the rest
+	phase, needed := NextPhase(rules, &conds)
+	if phase != "Healthy" {
+		t.Errorf("NextPhase() phase = %q, want %q", phase, "Healthy")
+	}
+	if want := []string{"Database"}; !slices.Equal(needed, want) {
+		t.Errorf("NextPhase() needed = %v, want %v", needed, want)
+	}
+}
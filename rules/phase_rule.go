@@ -2,6 +2,7 @@ package rules
 
 import (
 	"slices"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -19,37 +20,86 @@ type PhaseRule interface {
 
 	// ComputePhase checks if satisfies the rule, if not, return Unknown
 	ComputePhase(conditions *[]metav1.Condition) string
+
+	// ConditionTypes returns the condition types the rule depends on
+	ConditionTypes() sets.Set[string]
+
+	// Priority returns the rule's precedence, rules with a higher priority are evaluated first
+	Priority() int
+
+	// Explain returns a human-readable trace of which conditions matched, had the wrong status or were missing
+	Explain(conditions *[]metav1.Condition) string
+
+	// DeepCopy returns a copy of the rule sharing no mutable state with it
+	DeepCopy() PhaseRule
+}
+
+// MatchResult is the outcome of matching conditions. Besides matched and not matched, a matcher is unknown
+// when a condition type it requires is absent, to tell a condition with the wrong status from a missing one.
+type MatchResult int
+
+const (
+	MatcherNotMatched MatchResult = iota
+	MatcherMatched
+	MatcherUnknown
+)
+
+func (r MatchResult) String() string {
+	switch r {
+	case MatcherMatched:
+		return "Matched"
+	case MatcherNotMatched:
+		return "NotMatched"
+	default:
+		return "Unknown"
+	}
 }
 
 // ConditionMatcher matches a condition against a set of expected statuses
 // those statuses are, as of now, True, False and Unknown
 type ConditionMatcher interface {
-	// Match returns true if the (type, status) tuple matches one of the expected tuples
-	Matches(conditions *[]metav1.Condition) bool
+	// Matches returns MatcherMatched if the (type, status) tuple matches one of the expected tuples,
+	// MatcherUnknown if a condition type it requires is absent, nil conditions included, and MatcherNotMatched otherwise
+	Matches(conditions *[]metav1.Condition) MatchResult
 
 	// ConditionType returns the unerlying condition that this matcher is for
 	ConditionTypes() sets.Set[string]
 }
 
-type conditionEqualsMatcher struct {
-	condition string
-	statuses  []metav1.ConditionStatus
-}
-
-var _ ConditionMatcher = (*conditionEqualsMatcher)(nil)
-
-func (m *conditionEqualsMatcher) Matches(conditions *[]metav1.Condition) bool {
+// matchCondition matches the conditions of conditionType against match, unknown if there are none.
+func matchCondition(conditions *[]metav1.Condition, conditionType string, match func(metav1.Condition) bool) MatchResult {
 	if conditions == nil {
-		return false
+		return MatcherUnknown
 	}
 
+	result := MatcherUnknown
+
 	for _, condition := range *conditions {
-		if condition.Type == m.condition && slices.Contains(m.statuses, condition.Status) {
-			return true
+		if condition.Type != conditionType {
+			continue
 		}
+
+		if match(condition) {
+			return MatcherMatched
+		}
+
+		result = MatcherNotMatched
 	}
 
-	return false
+	return result
+}
+
+type conditionEqualsMatcher struct {
+	condition string
+	statuses  []metav1.ConditionStatus
+}
+
+var _ ConditionMatcher = (*conditionEqualsMatcher)(nil)
+
+func (m *conditionEqualsMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		return slices.Contains(m.statuses, condition.Status)
+	})
 }
 
 func (m *conditionEqualsMatcher) ConditionTypes() sets.Set[string] {
@@ -64,6 +114,326 @@ func ConditionEquals(condition string, statuses ...metav1.ConditionStatus) Condi
 	}
 }
 
+type conditionNotEqualsMatcher struct {
+	condition string
+	statuses  []metav1.ConditionStatus
+}
+
+var _ ConditionMatcher = (*conditionNotEqualsMatcher)(nil)
+
+func (m *conditionNotEqualsMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+// matchesMissing is MatcherNotMatched for the Unknown conditions a phase rule stands in for missing ones, a status
+// other than the given ones takes a present condition.
+func (m *conditionNotEqualsMatcher) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		return !missing.Has(condition.Type) && !slices.Contains(m.statuses, condition.Status)
+	})
+}
+
+func (m *conditionNotEqualsMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+// ConditionNotEquals returns a matcher for a condition type whose status is none of the given statuses,
+// e.g. ConditionNotEquals("Ready", metav1.ConditionTrue) for anything but Ready.
+// Only a present condition matches, a missing one is MatcherUnknown, and doesn't match inside a phase rule either,
+// as with ConditionExists, though the rule evaluates it as Unknown for the other matchers.
+func ConditionNotEquals(condition string, statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionNotEqualsMatcher{
+		condition: condition,
+		statuses:  statuses,
+	}
+}
+
+type conditionFreshlyEqualsMatcher struct {
+	condition  string
+	generation int64
+	statuses   []metav1.ConditionStatus
+}
+
+var _ ConditionMatcher = (*conditionFreshlyEqualsMatcher)(nil)
+
+func (m *conditionFreshlyEqualsMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		return condition.ObservedGeneration == m.generation && slices.Contains(m.statuses, condition.Status)
+	})
+}
+
+func (m *conditionFreshlyEqualsMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+// ConditionFreshlyEquals returns a matcher for a condition type that may equal any one of the given statuses
+// and was observed at generation, i.e. set during the reconcile of that generation.
+// Pass the object's current generation, object.GetGeneration(), to match conditions that just flipped.
+func ConditionFreshlyEquals(condition string, generation int64, statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionFreshlyEqualsMatcher{
+		condition:  condition,
+		generation: generation,
+		statuses:   statuses,
+	}
+}
+
+type conditionFreshMatcher struct {
+	condition  string
+	generation int64
+}
+
+var _ ConditionMatcher = (*conditionFreshMatcher)(nil)
+
+func (m *conditionFreshMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		return condition.ObservedGeneration >= m.generation
+	})
+}
+
+func (m *conditionFreshMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+// ConditionFresh returns a matcher for a condition type observed at generation or later, whatever its status,
+// e.g. ConditionsAll(ConditionEquals("Available", metav1.ConditionTrue), ConditionFresh("Available", generation))
+// to not compute Ready off a condition predating the latest spec change. Pass the object's current generation,
+// object.GetGeneration().
+func ConditionFresh(condition string, generation int64) ConditionMatcher {
+	return &conditionFreshMatcher{
+		condition:  condition,
+		generation: generation,
+	}
+}
+
+type conditionWithinGenerationsMatcher struct {
+	condition   string
+	generation  int64
+	generations int64
+	statuses    []metav1.ConditionStatus
+}
+
+var _ ConditionMatcher = (*conditionWithinGenerationsMatcher)(nil)
+
+func (m *conditionWithinGenerationsMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		return m.generation-condition.ObservedGeneration <= m.generations && slices.Contains(m.statuses, condition.Status)
+	})
+}
+
+func (m *conditionWithinGenerationsMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+// ConditionWithinGenerations returns a matcher for a condition type that may equal any one of the given statuses
+// and was observed at most k generations before generation, tolerating conditions left over from recent
+// generations, e.g. during a rolling update. k of 0 requires generation itself.
+// Pass the object's current generation, object.GetGeneration(), as with ConditionFreshlyEquals.
+func ConditionWithinGenerations(condition string, generation, k int64, statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionWithinGenerationsMatcher{
+		condition:   condition,
+		generation:  generation,
+		generations: k,
+		statuses:    statuses,
+	}
+}
+
+type conditionReasonMatcher struct {
+	condition string
+	reasons   []string
+}
+
+var _ ConditionMatcher = (*conditionReasonMatcher)(nil)
+
+func (m *conditionReasonMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+// matchesMissing is MatcherNotMatched for the Unknown conditions a phase rule stands in for missing ones, they have no
+// reason.
+func (m *conditionReasonMatcher) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		return !missing.Has(condition.Type) && slices.Contains(m.reasons, condition.Reason)
+	})
+}
+
+func (m *conditionReasonMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+// ConditionReasonIs returns a matcher for a condition type whose reason may equal any one of the given reasons.
+// The status of the condition is not considered.
+func ConditionReasonIs(condition string, reasons ...string) ConditionMatcher {
+	return &conditionReasonMatcher{
+		condition: condition,
+		reasons:   reasons,
+	}
+}
+
+type conditionReasonEqualsMatcher struct {
+	condition string
+	status    metav1.ConditionStatus
+	reasons   []string
+}
+
+var _ ConditionMatcher = (*conditionReasonEqualsMatcher)(nil)
+
+func (m *conditionReasonEqualsMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+// matchesMissing is MatcherNotMatched for the Unknown conditions a phase rule stands in for missing ones, they have no
+// reason.
+func (m *conditionReasonEqualsMatcher) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	return matchCondition(conditions, m.condition, func(condition metav1.Condition) bool {
+		return !missing.Has(condition.Type) && condition.Status == m.status && slices.Contains(m.reasons, condition.Reason)
+	})
+}
+
+func (m *conditionReasonEqualsMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+// ConditionReasonEquals returns a matcher for a condition type with the given status and a reason equal to any one
+// of the given reasons, e.g. ConditionReasonEquals("Ready", metav1.ConditionFalse, "Backoff") to tell Ready=False
+// for a backoff apart from other failures.
+func ConditionReasonEquals(condition string, status metav1.ConditionStatus, reasons ...string) ConditionMatcher {
+	return &conditionReasonEqualsMatcher{
+		condition: condition,
+		status:    status,
+		reasons:   reasons,
+	}
+}
+
+type conditionPrefixUniformMatcher struct {
+	prefix   string
+	statuses []metav1.ConditionStatus
+}
+
+var _ ConditionMatcher = (*conditionPrefixUniformMatcher)(nil)
+
+func (m *conditionPrefixUniformMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+func (m *conditionPrefixUniformMatcher) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	if conditions == nil {
+		return MatcherUnknown
+	}
+
+	var (
+		uniform metav1.ConditionStatus
+		found   bool
+	)
+
+	for _, condition := range *conditions {
+		// the Unknown conditions standing in for missing ones aren't present under the prefix
+		if !strings.HasPrefix(condition.Type, m.prefix) || missing.Has(condition.Type) {
+			continue
+		}
+
+		if !found {
+			uniform, found = condition.Status, true
+		} else if condition.Status != uniform {
+			return MatcherNotMatched
+		}
+	}
+
+	if !found {
+		// no condition with the prefix, nothing is uniform
+		return MatcherUnknown
+	}
+
+	if len(m.statuses) == 0 || slices.Contains(m.statuses, uniform) {
+		return MatcherMatched
+	}
+
+	return MatcherNotMatched
+}
+
+// ConditionTypes returns an empty set, the condition types under a prefix aren't known ahead of time.
+func (m *conditionPrefixUniformMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New[string]()
+}
+
+// ConditionPrefixUniform returns a matcher for all present conditions whose type starts with prefix sharing the same status.
+// If statuses are given, the shared status must also be one of them.
+// It is unknown when no condition carries the prefix; an empty prefix selects every condition.
+func ConditionPrefixUniform(prefix string, statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionPrefixUniformMatcher{
+		prefix:   prefix,
+		statuses: statuses,
+	}
+}
+
+type conditionPrefixMatcher struct {
+	prefix   string
+	statuses []metav1.ConditionStatus
+	any      bool
+}
+
+var _ ConditionMatcher = (*conditionPrefixMatcher)(nil)
+
+func (m *conditionPrefixMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+func (m *conditionPrefixMatcher) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	if conditions == nil {
+		return MatcherUnknown
+	}
+
+	result := MatcherUnknown
+
+	for _, condition := range *conditions {
+		// the Unknown conditions standing in for missing ones aren't present under the prefix
+		if !strings.HasPrefix(condition.Type, m.prefix) || missing.Has(condition.Type) {
+			continue
+		}
+
+		allowed := slices.Contains(m.statuses, condition.Status)
+
+		switch {
+		case m.any && allowed:
+			return MatcherMatched
+		case !m.any && !allowed:
+			return MatcherNotMatched
+		}
+
+		if m.any {
+			result = MatcherNotMatched
+		} else {
+			result = MatcherMatched
+		}
+	}
+
+	return result
+}
+
+// ConditionTypes returns an empty set, the condition types under a prefix aren't known ahead of time.
+func (m *conditionPrefixMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New[string]()
+}
+
+// ConditionPrefixAll returns a matcher for all present conditions whose type starts with prefix having one of
+// the given statuses, e.g. ConditionPrefixAll("Component/", metav1.ConditionTrue) for per-component conditions.
+// Like ConditionPrefixUniform it is unknown when no condition carries the prefix, a rule isn't satisfied by it then.
+func ConditionPrefixAll(prefix string, statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionPrefixMatcher{
+		prefix:   prefix,
+		statuses: statuses,
+	}
+}
+
+// ConditionPrefixAny returns a matcher for at least one present condition whose type starts with prefix having
+// one of the given statuses. It is unknown when no condition carries the prefix.
+func ConditionPrefixAny(prefix string, statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionPrefixMatcher{
+		prefix:   prefix,
+		statuses: statuses,
+		any:      true,
+	}
+}
+
 type conditionMatcherAll struct {
 	// a condition must match all the matcherReferences
 	matcherReferences []ConditionMatcher
@@ -71,18 +441,28 @@ type conditionMatcherAll struct {
 
 var _ ConditionMatcher = (*conditionMatcherAll)(nil)
 
-func (m *conditionMatcherAll) Matches(conditions *[]metav1.Condition) bool {
+func (m *conditionMatcherAll) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+func (m *conditionMatcherAll) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
 	if conditions == nil {
-		return false
+		return MatcherUnknown
 	}
 
+	result := MatcherMatched
+
 	for _, matcher := range m.matcherReferences {
-		if !matcher.Matches(conditions) {
-			return false
+		switch matches(matcher, conditions, missing) {
+		case MatcherNotMatched:
+			return MatcherNotMatched
+		case MatcherUnknown:
+			// a later matcher may still rule the group out
+			result = MatcherUnknown
 		}
 	}
 
-	return true
+	return result
 }
 
 func (m *conditionMatcherAll) ConditionTypes() sets.Set[string] {
@@ -108,18 +488,27 @@ type conditionMatcherAny struct {
 
 var _ ConditionMatcher = (*conditionMatcherAny)(nil)
 
-func (m *conditionMatcherAny) Matches(conditions *[]metav1.Condition) bool {
+func (m *conditionMatcherAny) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+func (m *conditionMatcherAny) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
 	if conditions == nil {
-		return false
+		return MatcherUnknown
 	}
 
+	result := MatcherNotMatched
+
 	for _, matcher := range m.matcherReferences {
-		if matcher.Matches(conditions) {
-			return true
+		switch matches(matcher, conditions, missing) {
+		case MatcherMatched:
+			return MatcherMatched
+		case MatcherUnknown:
+			result = MatcherUnknown
 		}
 	}
 
-	return false
+	return result
 }
 
 func (m *conditionMatcherAny) ConditionTypes() sets.Set[string] {
@@ -138,53 +527,266 @@ func ConditionsAny(matchers ...ConditionMatcher) ConditionMatcher {
 	}
 }
 
+type conditionMatcherAtLeast struct {
+	n int
+
+	// a condition must match at least n of the matcherReferences
+	matcherReferences []ConditionMatcher
+}
+
+var _ ConditionMatcher = (*conditionMatcherAtLeast)(nil)
+
+func (m *conditionMatcherAtLeast) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+func (m *conditionMatcherAtLeast) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	if conditions == nil {
+		return MatcherUnknown
+	}
+
+	matched, unknown := 0, 0
+
+	for _, matcher := range m.matcherReferences {
+		if matched >= m.n {
+			break
+		}
+
+		switch matches(matcher, conditions, missing) {
+		case MatcherMatched:
+			matched++
+		case MatcherUnknown:
+			unknown++
+		}
+	}
+
+	switch {
+	case matched >= m.n:
+		return MatcherMatched
+	case matched+unknown >= m.n:
+		// the absent conditions could still make up the quorum
+		return MatcherUnknown
+	default:
+		return MatcherNotMatched
+	}
+}
+
+func (m *conditionMatcherAtLeast) ConditionTypes() sets.Set[string] {
+	types := sets.New[string]()
+
+	for _, matcher := range m.matcherReferences {
+		types.DestructiveUnion(matcher.ConditionTypes())
+	}
+
+	return types
+}
+
+// ConditionsAtLeast returns a matcher for at least n of the given matchers matching, a quorum,
+// e.g. 2 of 3 replicas' conditions True. Every matcher counts once, independent of the condition types it
+// refers to: the same condition type in several matchers counts for each of them that matches.
+// n larger than the number of matchers never matches, n <= 0 matches any conditions.
+func ConditionsAtLeast(n int, matchers ...ConditionMatcher) ConditionMatcher {
+	return &conditionMatcherAtLeast{
+		n:                 n,
+		matcherReferences: matchers,
+	}
+}
+
+// DominantEffect is what a matching dominant matcher does to a ConditionsDominant matcher.
+type DominantEffect int
+
+const (
+	// DominantSatisfies matches as soon as the dominant matcher does.
+	DominantSatisfies DominantEffect = iota
+
+	// DominantFails never matches while the dominant matcher does.
+	DominantFails
+)
+
+type conditionMatcherDominant struct {
+	dominant ConditionMatcher
+	rest     ConditionMatcher
+	effect   DominantEffect
+}
+
+var _ ConditionMatcher = (*conditionMatcherDominant)(nil)
+
+func (m *conditionMatcherDominant) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+func (m *conditionMatcherDominant) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	if conditions == nil {
+		return MatcherUnknown
+	}
+
+	dominant := matches(m.dominant, conditions, missing)
+
+	if dominant == MatcherMatched {
+		if m.effect == DominantSatisfies {
+			return MatcherMatched
+		}
+
+		return MatcherNotMatched
+	}
+
+	rest := matches(m.rest, conditions, missing)
+
+	if dominant == MatcherUnknown {
+		// the dominant condition may yet decide, unless rest already agrees with it
+		if (m.effect == DominantSatisfies && rest == MatcherMatched) || (m.effect == DominantFails && rest == MatcherNotMatched) {
+			return rest
+		}
+
+		return MatcherUnknown
+	}
+
+	return rest
+}
+
+func (m *conditionMatcherDominant) ConditionTypes() sets.Set[string] {
+	types := sets.New[string]()
+	types.DestructiveUnion(m.dominant.ConditionTypes())
+	types.DestructiveUnion(m.rest.ConditionTypes())
+
+	return types
+}
+
+// ConditionsDominant returns a matcher for authoritative conditions overriding everything else,
+// e.g. Terminating=True: when dominant matches, the effect decides the outcome without evaluating rest,
+// otherwise rest does.
+func ConditionsDominant(dominant, rest ConditionMatcher, effect DominantEffect) ConditionMatcher {
+	return &conditionMatcherDominant{
+		dominant: dominant,
+		rest:     rest,
+		effect:   effect,
+	}
+}
+
 type phaseRuleSimple struct {
-	phase   string
-	matcher ConditionMatcher
+	phase    string
+	priority int
+	matcher  ConditionMatcher
+
+	summaryReason  string
+	summaryMessage string
 }
 
-var _ PhaseRule = (*phaseRuleSimple)(nil)
+var (
+	_ PhaseRule  = (*phaseRuleSimple)(nil)
+	_ Summarizer = (*phaseRuleSimple)(nil)
+)
 
-func NewPhaseRule(phase string, matcher ConditionMatcher) PhaseRule {
-	return &phaseRuleSimple{
-		phase:   phase,
-		matcher: matcher,
+// RuleOption configures a rule built with NewPhaseRule or NewPhaseRuleWithPriority.
+type RuleOption func(*phaseRuleSimple)
+
+// WithSummary gives the rule a reason and message explaining its phase, e.g. "DatabaseUnreachable" and
+// "the database is not reachable" for a Failed rule. The manager's summary condition carries them while the rule
+// decides the phase, either can be empty to keep the manager's own. reason must be a valid condition reason, CamelCase.
+func WithSummary(reason, message string) RuleOption {
+	return func(r *phaseRuleSimple) {
+		r.summaryReason = reason
+		r.summaryMessage = message
 	}
 }
 
+// Summarizer is implemented by rules that can explain their phase, see WithSummary.
+type Summarizer interface {
+	// Summary returns the rule's reason and message, empty if it has none
+	Summary() (reason, message string)
+}
+
+func NewPhaseRule(phase string, matcher ConditionMatcher, opts ...RuleOption) PhaseRule {
+	return NewPhaseRuleWithPriority(phase, 0, matcher, opts...)
+}
+
+// NewPhaseRuleWithPriority returns a rule evaluated before the rules with a lower priority, regardless of where
+// it is in the list of rules. Rules of equal priority keep their order. NewPhaseRule rules have priority 0.
+func NewPhaseRuleWithPriority(phase string, priority int, matcher ConditionMatcher, opts ...RuleOption) PhaseRule {
+	r := &phaseRuleSimple{
+		phase:    phase,
+		priority: priority,
+		matcher:  matcher,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *phaseRuleSimple) Summary() (string, string) {
+	return r.summaryReason, r.summaryMessage
+}
+
 func (r *phaseRuleSimple) Satisfies(conditions *[]metav1.Condition) bool {
 	if conditions == nil {
 		return false
 	}
 
+	stateConditions, missing := withUnknownConditions(*conditions, r.matcher.ConditionTypes())
+
+	return matches(r.matcher, &stateConditions, missing) == MatcherMatched
+}
+
+// missingAwareMatcher is implemented by the matchers telling the Unknown conditions a phase rule stands in for
+// missing ones from Unknown conditions that are present, and by the matchers combining others.
+type missingAwareMatcher interface {
+	// matchesMissing is Matches, with the conditions of the types in missing standing in for missing ones
+	matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult
+}
+
+// matches matches conditions against matcher, the conditions of the types in missing stand in for missing ones.
+func matches(matcher ConditionMatcher, conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	if m, ok := matcher.(missingAwareMatcher); ok {
+		return m.matchesMissing(conditions, missing)
+	}
+
+	return matcher.Matches(conditions)
+}
+
+// withUnknownConditions returns conditions with an Unknown condition appended for each of the domain types missing
+// from them, and the types it appended.
+func withUnknownConditions(conditions []metav1.Condition, domainConditions sets.Set[string]) ([]metav1.Condition, sets.Set[string]) {
 	conditionSet := sets.New[string]()
 
-	for _, condition := range *conditions {
+	for _, condition := range conditions {
 		conditionSet.Insert(condition.Type)
 	}
 
-	domainConditions := r.matcher.ConditionTypes()
-
-	stateConditions := *conditions
+	stateConditions := conditions
+	missing := sets.New[string]()
 
 	for domainCondition := range domainConditions {
 		if conditionSet.Has(domainCondition) {
 			continue
 		}
 
+		missing.Insert(domainCondition)
+
 		stateConditions = append(stateConditions, metav1.Condition{
 			Type:   domainCondition,
 			Status: metav1.ConditionUnknown,
 		}) // don't care for the other fields
 	}
 
-	return r.matcher.Matches(&stateConditions)
+	return stateConditions, missing
+}
+
+// ConditionTypes returns the condition types the rule's matcher refers to.
+func (r *phaseRuleSimple) ConditionTypes() sets.Set[string] {
+	return r.matcher.ConditionTypes()
 }
 
 func (r *phaseRuleSimple) Phase() string {
 	return r.phase
 }
 
+func (r *phaseRuleSimple) Priority() int {
+	return r.priority
+}
+
 func (r *phaseRuleSimple) ComputePhase(conditions *[]metav1.Condition) string {
 	if r.Satisfies(conditions) {
 		return r.Phase()
@@ -192,3 +794,109 @@ func (r *phaseRuleSimple) ComputePhase(conditions *[]metav1.Condition) string {
 
 	return PhaseUnknown
 }
+
+type phaseRuleNegated struct {
+	phase string
+	base  PhaseRule
+}
+
+var _ PhaseRule = (*phaseRuleNegated)(nil)
+
+// Negate returns a rule for phase that is satisfied exactly when base is not.
+// Like every other rule it is never satisfied by nil conditions, absent conditions are no information to negate;
+// an empty, non nil, slice of conditions is negated like any other.
+func Negate(base PhaseRule, phase string) PhaseRule {
+	return &phaseRuleNegated{
+		phase: phase,
+		base:  base,
+	}
+}
+
+func (r *phaseRuleNegated) Satisfies(conditions *[]metav1.Condition) bool {
+	if conditions == nil {
+		return false
+	}
+
+	return !r.base.Satisfies(conditions)
+}
+
+// ConditionTypes returns the condition types the base rule refers to.
+func (r *phaseRuleNegated) ConditionTypes() sets.Set[string] {
+	return r.base.ConditionTypes()
+}
+
+func (r *phaseRuleNegated) Phase() string {
+	return r.phase
+}
+
+// Priority is 0, the negated rule doesn't take the base's place in the evaluation order.
+func (r *phaseRuleNegated) Priority() int {
+	return 0
+}
+
+func (r *phaseRuleNegated) ComputePhase(conditions *[]metav1.Condition) string {
+	if r.Satisfies(conditions) {
+		return r.Phase()
+	}
+
+	return PhaseUnknown
+}
+
+type phaseRuleCombined struct {
+	phase string
+	rules []PhaseRule
+}
+
+var _ PhaseRule = (*phaseRuleCombined)(nil)
+
+// CombineRules returns a rule for phase that is satisfied when any of rules is, e.g. to reuse several existing
+// rules meaning "Ready" without repeating the phase or flattening them into a single matcher. The phases of rules
+// are ignored, the combined rule reports phase.
+func CombineRules(phase string, rules ...PhaseRule) PhaseRule {
+	return &phaseRuleCombined{
+		phase: phase,
+		rules: rules,
+	}
+}
+
+func (r *phaseRuleCombined) Satisfies(conditions *[]metav1.Condition) bool {
+	if conditions == nil {
+		return false
+	}
+
+	for _, rule := range r.rules {
+		if rule.Satisfies(conditions) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ConditionTypes returns the condition types any of the combined rules refers to.
+func (r *phaseRuleCombined) ConditionTypes() sets.Set[string] {
+	types := sets.New[string]()
+
+	for _, rule := range r.rules {
+		types.DestructiveUnion(rule.ConditionTypes())
+	}
+
+	return types
+}
+
+func (r *phaseRuleCombined) Phase() string {
+	return r.phase
+}
+
+// Priority is 0, the priorities of the combined rules only order them among the other rules, not here.
+func (r *phaseRuleCombined) Priority() int {
+	return 0
+}
+
+func (r *phaseRuleCombined) ComputePhase(conditions *[]metav1.Condition) string {
+	if r.Satisfies(conditions) {
+		return r.Phase()
+	}
+
+	return PhaseUnknown
+}
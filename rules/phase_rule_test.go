@@ -321,14 +321,14 @@ func TestConditionEquals_MultipleStatuses_AllReturnedCorrectly(t *testing.T) {
 	}
 	for _, status := range []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown} {
 		c := &metav1.Condition{Type: "X", Status: status}
-		if matcher.Matches(&[]metav1.Condition{*c}) != true {
+		if matcher.Matches(&[]metav1.Condition{*c}) != MatcherMatched {
 			t.Errorf("expected MatcherMatched for status %v", status)
 		}
 	}
 	// False status not in allowed list (we only have True, False, Unknown - so any other would fail; use a wrong type)
 	wrongType := &metav1.Condition{Type: "Y", Status: metav1.ConditionTrue}
-	if matcher.Matches(&[]metav1.Condition{*wrongType}) != false {
-		t.Error("expected MatcherNotMatched for wrong condition type")
+	if matcher.Matches(&[]metav1.Condition{*wrongType}) != MatcherUnknown {
+		t.Error("expected MatcherUnknown for wrong condition type, X is absent")
 	}
 }
 
@@ -348,6 +348,36 @@ func TestConditionsAll_MultipleMatcherGroups(t *testing.T) {
 	}
 }
 
+// ---- ConditionsAll leaves are a conjunction, statuses of one leaf a disjunction ----
+
+func TestConditionsAll_ConflictingLeavesUnsatisfiable(t *testing.T) {
+	rule := NewPhaseRule("Impossible", ConditionsAll(
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("A", metav1.ConditionFalse),
+	))
+	for _, status := range []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown} {
+		conds := []metav1.Condition{cond("A", status)}
+		if rule.Satisfies(&conds) {
+			t.Errorf("expected false for A=%s, A cannot be both True and False", status)
+		}
+	}
+}
+
+func TestConditionsAll_OneLeafManyStatuses(t *testing.T) {
+	rule := NewPhaseRule("Settled", ConditionsAll(
+		ConditionEquals("A", metav1.ConditionTrue, metav1.ConditionFalse),
+	))
+	for _, status := range []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse} {
+		conds := []metav1.Condition{cond("A", status)}
+		if !rule.Satisfies(&conds) {
+			t.Errorf("expected true for A=%s, A may be True or False", status)
+		}
+	}
+	if rule.Satisfies(&[]metav1.Condition{cond("A", metav1.ConditionUnknown)}) {
+		t.Error("expected false for A=Unknown")
+	}
+}
+
 // ---- ConditionsAny with multiple matcher groups ----
 
 func TestConditionsAny_MultipleMatcherGroups(t *testing.T) {
@@ -508,6 +538,236 @@ func TestRecursed_ConditionsAnyEmptyInnerAndEquals(t *testing.T) {
 	}
 }
 
+// ---- OR-of-ALL: ConditionsAny(ConditionsAll(...), ConditionsAll(...)) ----
+
+func orOfAllRule() PhaseRule {
+	// (A and B) or (C and D)
+	return NewPhaseRule("Ready", ConditionsAny(
+		ConditionsAll(
+			ConditionEquals("A", metav1.ConditionTrue),
+			ConditionEquals("B", metav1.ConditionTrue),
+		),
+		ConditionsAll(
+			ConditionEquals("C", metav1.ConditionTrue),
+			ConditionEquals("D", metav1.ConditionTrue),
+		),
+	))
+}
+
+func TestOrOfAll_FirstGroupMatches(t *testing.T) {
+	conds := []metav1.Condition{
+		cond("A", metav1.ConditionTrue),
+		cond("B", metav1.ConditionTrue),
+		cond("C", metav1.ConditionFalse),
+	}
+	if !orOfAllRule().Satisfies(&conds) {
+		t.Error("expected true when A and B match, D missing")
+	}
+}
+
+func TestOrOfAll_SecondGroupMatches(t *testing.T) {
+	conds := []metav1.Condition{
+		cond("A", metav1.ConditionTrue),
+		cond("B", metav1.ConditionFalse),
+		cond("C", metav1.ConditionTrue),
+		cond("D", metav1.ConditionTrue),
+	}
+	if !orOfAllRule().Satisfies(&conds) {
+		t.Error("expected true when C and D match, first group only partially")
+	}
+}
+
+func TestOrOfAll_NoGroupFullyMatches(t *testing.T) {
+	// one condition of each group matches, which satisfies neither group
+	conds := []metav1.Condition{
+		cond("A", metav1.ConditionTrue),
+		cond("B", metav1.ConditionFalse),
+		cond("C", metav1.ConditionTrue),
+		cond("D", metav1.ConditionUnknown),
+	}
+	if orOfAllRule().Satisfies(&conds) {
+		t.Error("expected false when neither group fully matches")
+	}
+	if got := orOfAllRule().ComputePhase(&conds); got != PhaseUnknown {
+		t.Errorf("ComputePhase() = %q, want %q", got, PhaseUnknown)
+	}
+}
+
+// ---- ConditionsAtLeast ----
+
+func TestConditionsAtLeast_Quorum(t *testing.T) {
+	rule := NewPhaseRule("PartiallyReady", ConditionsAtLeast(2,
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("B", metav1.ConditionTrue),
+		ConditionEquals("C", metav1.ConditionTrue),
+	))
+
+	tests := []struct {
+		name  string
+		conds []metav1.Condition
+		want  bool
+	}{
+		{"one of three", []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse)}, false},
+		{"two of three", []metav1.Condition{cond("A", metav1.ConditionTrue), cond("C", metav1.ConditionTrue)}, true},
+		{"three of three", []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue), cond("C", metav1.ConditionTrue)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.Satisfies(&tt.conds); got != tt.want {
+				t.Errorf("Satisfies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionsAtLeast_Bounds(t *testing.T) {
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue)}
+
+	tooMany := NewPhaseRule("Never", ConditionsAtLeast(3,
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("B", metav1.ConditionTrue),
+	))
+	if tooMany.Satisfies(&conds) {
+		t.Error("expected false when n is larger than the number of matchers")
+	}
+
+	for _, n := range []int{0, -1} {
+		none := NewPhaseRule("Always", ConditionsAtLeast(n, ConditionEquals("A", metav1.ConditionFalse)))
+		if !none.Satisfies(&conds) {
+			t.Errorf("expected true for n = %d", n)
+		}
+	}
+}
+
+func TestConditionsAtLeast_SameTypeCountsPerMatcher(t *testing.T) {
+	rule := NewPhaseRule("Settled", ConditionsAtLeast(2,
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("A", metav1.ConditionTrue, metav1.ConditionFalse),
+		ConditionEquals("B", metav1.ConditionTrue),
+	))
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse)}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true, A=True matches two matchers")
+	}
+}
+
+// ---- ConditionsDominant ----
+
+func TestConditionsDominant_Satisfies(t *testing.T) {
+	rule := NewPhaseRule("Terminating", ConditionsDominant(
+		ConditionEquals("Terminating", metav1.ConditionTrue),
+		ConditionsAll(ConditionEquals("Drained", metav1.ConditionTrue)),
+		DominantSatisfies,
+	))
+
+	// dominant present: rest doesn't matter
+	conds := []metav1.Condition{cond("Terminating", metav1.ConditionTrue), cond("Drained", metav1.ConditionFalse)}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when the dominant condition matches")
+	}
+
+	// dominant absent: rest decides
+	conds = []metav1.Condition{cond("Drained", metav1.ConditionTrue)}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when the dominant condition is missing and rest matches")
+	}
+	conds = []metav1.Condition{cond("Terminating", metav1.ConditionFalse), cond("Drained", metav1.ConditionFalse)}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when neither the dominant condition nor rest matches")
+	}
+}
+
+func TestConditionsDominant_Fails(t *testing.T) {
+	rule := NewPhaseRule("Ready", ConditionsDominant(
+		ConditionEquals("Terminating", metav1.ConditionTrue),
+		ConditionsAll(ConditionEquals("Available", metav1.ConditionTrue)),
+		DominantFails,
+	))
+
+	// dominant present: fails even though rest matches
+	conds := []metav1.Condition{cond("Terminating", metav1.ConditionTrue), cond("Available", metav1.ConditionTrue)}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when the dominant condition matches")
+	}
+
+	// dominant absent: rest decides
+	conds = []metav1.Condition{cond("Available", metav1.ConditionTrue)}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when the dominant condition is missing and rest matches")
+	}
+}
+
+// ---- MatchResult ----
+
+func TestMatches_TriState(t *testing.T) {
+	a := ConditionEquals("A", metav1.ConditionTrue)
+	b := ConditionEquals("B", metav1.ConditionTrue)
+
+	tests := []struct {
+		name    string
+		matcher ConditionMatcher
+		conds   *[]metav1.Condition
+		want    MatchResult
+	}{
+		{"leaf matched", a, &[]metav1.Condition{cond("A", metav1.ConditionTrue)}, MatcherMatched},
+		{"leaf present with the wrong status", a, &[]metav1.Condition{cond("A", metav1.ConditionFalse)}, MatcherNotMatched},
+		{"leaf absent", a, &[]metav1.Condition{cond("B", metav1.ConditionTrue)}, MatcherUnknown},
+		{"nil conditions", a, nil, MatcherUnknown},
+		{"all with one absent", ConditionsAll(a, b), &[]metav1.Condition{cond("A", metav1.ConditionTrue)}, MatcherUnknown},
+		{"all with one absent and one wrong", ConditionsAll(a, b), &[]metav1.Condition{cond("B", metav1.ConditionFalse)}, MatcherNotMatched},
+		{"any with one absent and one wrong", ConditionsAny(a, b), &[]metav1.Condition{cond("B", metav1.ConditionFalse)}, MatcherUnknown},
+		{"any with one absent and one matched", ConditionsAny(a, b), &[]metav1.Condition{cond("B", metav1.ConditionTrue)}, MatcherMatched},
+		{"quorum reachable with absent", ConditionsAtLeast(2, a, b), &[]metav1.Condition{cond("A", metav1.ConditionTrue)}, MatcherUnknown},
+		{"quorum unreachable", ConditionsAtLeast(2, a, b), &[]metav1.Condition{cond("A", metav1.ConditionFalse)}, MatcherNotMatched},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.matcher.Matches(tt.conds); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSatisfies_AbsentIsUnknownStatus(t *testing.T) {
+	// the rule evaluates the absent condition as Unknown, which a matcher may ask for
+	rule := NewPhaseRule("Pending", ConditionEquals("A", metav1.ConditionUnknown))
+	if !rule.Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected true, a missing A is evaluated as Unknown")
+	}
+}
+
+// ---- ConditionTypes ----
+
+func TestPhaseRule_ConditionTypesNested(t *testing.T) {
+	rule := NewPhaseRule("Ready", ConditionsAll(
+		ConditionsAny(ConditionEquals("A", metav1.ConditionTrue), ConditionsAll(ConditionEquals("B", metav1.ConditionTrue))),
+		ConditionEquals("C", metav1.ConditionTrue),
+		ConditionEquals("A", metav1.ConditionFalse),
+	))
+
+	types := rule.ConditionTypes()
+	if types.Len() != 3 || !types.Has("A") || !types.Has("B") || !types.Has("C") {
+		t.Errorf("ConditionTypes() = %v, want A, B and C", types)
+	}
+
+	if negated := Negate(rule, "NotReady").ConditionTypes(); negated.Len() != 3 {
+		t.Errorf("Negate().ConditionTypes() = %v, want the base rule's types", negated)
+	}
+}
+
+func TestConditionTypes_Rules(t *testing.T) {
+	types := ConditionTypes([]PhaseRule{
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue))),
+		NewPhaseRule("Failed", ConditionsAny(ConditionEquals("A", metav1.ConditionFalse), ConditionEquals("B", metav1.ConditionFalse))),
+	})
+	if types.Len() != 2 || !types.Has("A") || !types.Has("B") {
+		t.Errorf("ConditionTypes() = %v, want A and B", types)
+	}
+}
+
 // ---- PhaseUnknown constant ----
 
 func TestPhaseUnknown(t *testing.T) {
@@ -515,3 +775,496 @@ func TestPhaseUnknown(t *testing.T) {
 		t.Errorf("PhaseUnknown = %q, want Unknown", PhaseUnknown)
 	}
 }
+
+// ---- ConditionReasonIs ----
+
+func TestConditionReasonIs_IgnoresStatus(t *testing.T) {
+	rule := NewPhaseRule("BackingOff", ConditionsAll(
+		ConditionReasonIs("A", "Backoff", "CrashLoop"),
+	))
+	for _, status := range []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown} {
+		conds := []metav1.Condition{{Type: "A", Status: status, Reason: "Backoff"}}
+		if !rule.Satisfies(&conds) {
+			t.Errorf("expected true for reason Backoff with status %v", status)
+		}
+	}
+	conds := []metav1.Condition{{Type: "A", Status: metav1.ConditionFalse, Reason: "CrashLoop"}}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when reason is any one of the allowed reasons")
+	}
+}
+
+func TestConditionReasonIs_WrongReason(t *testing.T) {
+	rule := NewPhaseRule("BackingOff", ConditionsAll(
+		ConditionReasonIs("A", "Backoff"),
+	))
+	conds := []metav1.Condition{{Type: "A", Status: metav1.ConditionFalse, Reason: "ImagePull"}}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when reason does not match")
+	}
+	conds = []metav1.Condition{{Type: "B", Status: metav1.ConditionFalse, Reason: "Backoff"}}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when only another condition type carries the reason")
+	}
+}
+
+func TestConditionReasonIs_Missing(t *testing.T) {
+	rule := NewPhaseRule("BackingOff", ConditionsAll(
+		ConditionReasonIs("A", "Backoff"),
+	))
+	if rule.Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected false when condition is missing")
+	}
+
+	// the Unknown the rule stands in for a missing A has no reason to match
+	noReason := NewPhaseRule("Unreasoned", ConditionReasonIs("A", ""))
+	if noReason.Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected false for an empty reason when condition is missing")
+	}
+	if !noReason.Satisfies(&[]metav1.Condition{cond("A", metav1.ConditionTrue)}) {
+		t.Error("expected true for an empty reason when condition is present without one")
+	}
+	if types := ConditionReasonIs("A", "Backoff").ConditionTypes(); !types.Has("A") || types.Len() != 1 {
+		t.Errorf("ConditionTypes() = %v, want set containing only A", types)
+	}
+}
+
+// ---- ConditionReasonEquals ----
+
+func TestConditionReasonEquals_StatusAndReason(t *testing.T) {
+	rule := NewPhaseRule("BackingOff", ConditionReasonEquals("Ready", metav1.ConditionFalse, "Backoff", "CrashLoop"))
+
+	tests := []struct {
+		name   string
+		status metav1.ConditionStatus
+		reason string
+		want   bool
+	}{
+		{"status and reason", metav1.ConditionFalse, "Backoff", true},
+		{"any of the reasons", metav1.ConditionFalse, "CrashLoop", true},
+		{"same status, other reason", metav1.ConditionFalse, "ImagePull", false},
+		{"same reason, other status", metav1.ConditionTrue, "Backoff", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conds := []metav1.Condition{{Type: "Ready", Status: tt.status, Reason: tt.reason}}
+			if got := rule.Satisfies(&conds); got != tt.want {
+				t.Errorf("Satisfies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionReasonEquals_Missing(t *testing.T) {
+	matcher := ConditionReasonEquals("Ready", metav1.ConditionFalse, "Backoff")
+
+	if got := matcher.Matches(&[]metav1.Condition{}); got != MatcherUnknown {
+		t.Errorf("Matches() = %v, want %v for a missing condition", got, MatcherUnknown)
+	}
+
+	// the Unknown a rule stands in for a missing Ready has no reason to match
+	if NewPhaseRule("Probing", ConditionReasonEquals("Ready", metav1.ConditionUnknown, "")).Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected false in a rule for a missing condition")
+	}
+	if types := matcher.ConditionTypes(); !types.Has("Ready") || types.Len() != 1 {
+		t.Errorf("ConditionTypes() = %v, want set containing only Ready", types)
+	}
+}
+
+func TestConditionReasonEquals_AndsWithStatusMatcher(t *testing.T) {
+	rule := NewPhaseRule("BackingOff", ConditionsAll(
+		ConditionEquals("Ready", metav1.ConditionFalse),
+		ConditionReasonIs("Ready", "Backoff"),
+	))
+
+	conds := []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: "ImagePull"}}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when the status matches but the reason does not")
+	}
+	conds = []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Backoff"}}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when both the status and the reason match")
+	}
+}
+
+// ---- ConditionPrefixUniform ----
+
+func TestConditionPrefixUniform_Uniform(t *testing.T) {
+	rule := NewPhaseRule("Settled", ConditionsAll(ConditionPrefixUniform("dependency/")))
+	conds := []metav1.Condition{
+		cond("dependency/db", metav1.ConditionFalse),
+		cond("dependency/cache", metav1.ConditionFalse),
+		cond("Ready", metav1.ConditionTrue),
+	}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when all prefixed conditions share a status")
+	}
+}
+
+func TestConditionPrefixUniform_Mixed(t *testing.T) {
+	rule := NewPhaseRule("Settled", ConditionsAll(ConditionPrefixUniform("dependency/")))
+	conds := []metav1.Condition{
+		cond("dependency/db", metav1.ConditionTrue),
+		cond("dependency/cache", metav1.ConditionUnknown),
+	}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when prefixed conditions have mixed statuses")
+	}
+}
+
+func TestConditionPrefixUniform_PinnedStatus(t *testing.T) {
+	rule := NewPhaseRule("DependenciesReady", ConditionsAll(ConditionPrefixUniform("dependency/", metav1.ConditionTrue)))
+	conds := []metav1.Condition{
+		cond("dependency/db", metav1.ConditionTrue),
+		cond("dependency/cache", metav1.ConditionTrue),
+	}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when prefixed conditions are uniformly the pinned status")
+	}
+	conds = []metav1.Condition{
+		cond("dependency/db", metav1.ConditionFalse),
+		cond("dependency/cache", metav1.ConditionFalse),
+	}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when prefixed conditions are uniform but not the pinned status")
+	}
+}
+
+func TestConditionPrefixUniform_NoPrefixedConditions(t *testing.T) {
+	rule := NewPhaseRule("Settled", ConditionsAll(ConditionPrefixUniform("dependency/")))
+	conds := []metav1.Condition{cond("Ready", metav1.ConditionTrue)}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when no condition carries the prefix")
+	}
+	if rule.Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected false for empty conditions")
+	}
+}
+
+func TestConditionPrefixUniform_EmptyPrefix(t *testing.T) {
+	matcher := ConditionPrefixUniform("")
+	conds := []metav1.Condition{
+		cond("A", metav1.ConditionTrue),
+		cond("B", metav1.ConditionTrue),
+	}
+	if matcher.Matches(&conds) != MatcherMatched {
+		t.Error("expected empty prefix to select every condition")
+	}
+	conds = append(conds, cond("C", metav1.ConditionFalse))
+	if matcher.Matches(&conds) != MatcherNotMatched {
+		t.Error("expected false when any condition differs under an empty prefix")
+	}
+}
+
+func TestConditionPrefixUniform_InRuleWithMissing(t *testing.T) {
+	// dependency/a is missing, the Unknown the rule stands in for it isn't under the prefix
+	rule := NewPhaseRule("DependenciesReady", ConditionsAny(
+		ConditionEquals("dependency/a", metav1.ConditionTrue),
+		ConditionPrefixUniform("dependency/", metav1.ConditionTrue),
+	))
+	conds := []metav1.Condition{cond("dependency/b", metav1.ConditionTrue)}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true, dependency/b is the only present prefixed condition")
+	}
+}
+
+func TestConditionPrefixUniform_EmptyStatus(t *testing.T) {
+	conds := []metav1.Condition{{Type: "dependency/a"}, cond("dependency/b", metav1.ConditionTrue)}
+	if got := ConditionPrefixUniform("dependency/").Matches(&conds); got != MatcherNotMatched {
+		t.Errorf("Matches() = %v, want %v, an empty status differs from True", got, MatcherNotMatched)
+	}
+}
+
+// ---- ConditionPrefixAll / ConditionPrefixAny ----
+
+func TestConditionPrefixAllAny(t *testing.T) {
+	tests := []struct {
+		name    string
+		conds   []metav1.Condition
+		wantAll MatchResult
+		wantAny MatchResult
+	}{
+		{"all True", []metav1.Condition{
+			cond("Component/foo/Ready", metav1.ConditionTrue),
+			cond("Component/bar/Ready", metav1.ConditionTrue),
+			cond("Degraded", metav1.ConditionFalse),
+		}, MatcherMatched, MatcherMatched},
+		{"one False", []metav1.Condition{
+			cond("Component/foo/Ready", metav1.ConditionTrue),
+			cond("Component/bar/Ready", metav1.ConditionFalse),
+		}, MatcherNotMatched, MatcherMatched},
+		{"none True", []metav1.Condition{
+			cond("Component/foo/Ready", metav1.ConditionUnknown),
+			cond("Component/bar/Ready", metav1.ConditionFalse),
+		}, MatcherNotMatched, MatcherNotMatched},
+		{"none prefixed", []metav1.Condition{cond("Ready", metav1.ConditionTrue)}, MatcherUnknown, MatcherUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConditionPrefixAll("Component/", metav1.ConditionTrue).Matches(&tt.conds); got != tt.wantAll {
+				t.Errorf("ConditionPrefixAll().Matches() = %v, want %v", got, tt.wantAll)
+			}
+			if got := ConditionPrefixAny("Component/", metav1.ConditionTrue).Matches(&tt.conds); got != tt.wantAny {
+				t.Errorf("ConditionPrefixAny().Matches() = %v, want %v", got, tt.wantAny)
+			}
+		})
+	}
+
+	if got := ConditionPrefixAll("Component/", metav1.ConditionTrue).Matches(nil); got != MatcherUnknown {
+		t.Errorf("ConditionPrefixAll().Matches(nil) = %v, want %v", got, MatcherUnknown)
+	}
+}
+
+func TestConditionPrefixAll_IgnoresMissingReferencedConditions(t *testing.T) {
+	// Component/baz is referenced but missing, the rule's stand-in Unknown condition isn't under the prefix
+	rule := NewPhaseRule("Ready", ConditionsAny(
+		ConditionPrefixAll("Component/", metav1.ConditionTrue),
+		ConditionEquals("Component/baz/Ready", metav1.ConditionFalse),
+	))
+	conds := []metav1.Condition{cond("Component/foo/Ready", metav1.ConditionTrue)}
+
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when every present prefixed condition is True")
+	}
+}
+
+// ---- Negate ----
+
+func TestNegate_ComplementsBase(t *testing.T) {
+	ready := NewPhaseRule("Ready", ConditionsAll(
+		ConditionEquals("A", metav1.ConditionTrue),
+		ConditionEquals("B", metav1.ConditionTrue),
+	))
+	notReady := Negate(ready, "NotReady")
+
+	if got := notReady.Phase(); got != "NotReady" {
+		t.Errorf("Phase() = %q, want %q", got, "NotReady")
+	}
+
+	statuses := []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown}
+	for _, a := range statuses {
+		for _, b := range statuses {
+			conds := []metav1.Condition{cond("A", a), cond("B", b)}
+			if ready.Satisfies(&conds) == notReady.Satisfies(&conds) {
+				t.Errorf("A=%v B=%v: Ready and NotReady must not agree", a, b)
+			}
+		}
+	}
+}
+
+func TestNegate_EmptyAndNilConditions(t *testing.T) {
+	ready := NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue)))
+	notReady := Negate(ready, "NotReady")
+
+	if notReady.Satisfies(nil) {
+		t.Error("expected false for nil conditions, like every other rule")
+	}
+	if !notReady.Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected true for empty conditions, A is missing so Ready is not satisfied")
+	}
+	if got := notReady.ComputePhase(&[]metav1.Condition{cond("A", metav1.ConditionTrue)}); got != PhaseUnknown {
+		t.Errorf("ComputePhase() = %q, want %q", got, PhaseUnknown)
+	}
+}
+
+// ---- CombineRules ----
+
+func TestCombineRules_AnyRule(t *testing.T) {
+	ready := CombineRules("Ready",
+		NewPhaseRule("Serving", ConditionEquals("A", metav1.ConditionTrue)),
+		NewPhaseRule("Idle", ConditionsAll(ConditionEquals("A", metav1.ConditionFalse), ConditionReasonIs("A", "ScaledToZero"))),
+		Negate(NewPhaseRule("Configured", ConditionExists("B")), "Unconfigured"),
+	)
+
+	tests := []struct {
+		name  string
+		conds *[]metav1.Condition
+		want  string
+	}{
+		{"first rule", &[]metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue)}, "Ready"},
+		{"second rule", &[]metav1.Condition{{Type: "A", Status: metav1.ConditionFalse, Reason: "ScaledToZero"}, cond("B", metav1.ConditionTrue)}, "Ready"},
+		{"negated rule", &[]metav1.Condition{cond("A", metav1.ConditionFalse)}, "Ready"},
+		{"no rule", &[]metav1.Condition{cond("A", metav1.ConditionFalse), cond("B", metav1.ConditionTrue)}, PhaseUnknown},
+		{"nil conditions", nil, PhaseUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ready.ComputePhase(tt.conds); got != tt.want {
+				t.Errorf("ComputePhase() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := ready.ConditionTypes(); got.Len() != 2 || !got.Has("A") || !got.Has("B") {
+		t.Errorf("ConditionTypes() = %v, want A and B", got)
+	}
+	if CombineRules("Ready").Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected no rules to combine to never be satisfied")
+	}
+}
+
+// ---- ConditionNotEquals ----
+
+func TestConditionNotEquals_Present(t *testing.T) {
+	matcher := ConditionNotEquals("A", metav1.ConditionTrue)
+	for status, want := range map[metav1.ConditionStatus]MatchResult{
+		metav1.ConditionTrue:    MatcherNotMatched,
+		metav1.ConditionFalse:   MatcherMatched,
+		metav1.ConditionUnknown: MatcherMatched,
+	} {
+		conds := []metav1.Condition{cond("A", status)}
+		if got := matcher.Matches(&conds); got != want {
+			t.Errorf("Matches() for A=%s = %v, want %v", status, got, want)
+		}
+	}
+}
+
+func TestConditionNotEquals_Missing(t *testing.T) {
+	conds := []metav1.Condition{cond("B", metav1.ConditionFalse)}
+
+	// the matcher alone only applies to a present condition
+	if ConditionNotEquals("A", metav1.ConditionTrue).Matches(&conds) != MatcherUnknown {
+		t.Error("expected the matcher to be unknown for a missing condition")
+	}
+
+	// nor does a missing condition match inside a rule, though the rule evaluates it as Unknown
+	if NewPhaseRule("Degraded", ConditionNotEquals("A", metav1.ConditionTrue)).Satisfies(&conds) {
+		t.Error("expected false in a rule for a missing A")
+	}
+	if !NewPhaseRule("Degraded", ConditionNotEquals("A", metav1.ConditionTrue)).Satisfies(&[]metav1.Condition{cond("A", metav1.ConditionUnknown)}) {
+		t.Error("expected true in a rule for a present Unknown A")
+	}
+}
+
+func TestConditionNotEquals_InAllAndAny(t *testing.T) {
+	conds := []metav1.Condition{cond("A", metav1.ConditionFalse), cond("B", metav1.ConditionTrue)}
+
+	all := NewPhaseRule("Degraded", ConditionsAll(
+		ConditionNotEquals("A", metav1.ConditionTrue),
+		ConditionEquals("B", metav1.ConditionTrue),
+	))
+	if !all.Satisfies(&conds) {
+		t.Error("expected true when A is not True and B is True")
+	}
+
+	anyRule := NewPhaseRule("Degraded", ConditionsAny(
+		ConditionNotEquals("A", metav1.ConditionFalse),
+		ConditionNotEquals("B", metav1.ConditionTrue),
+	))
+	if anyRule.Satisfies(&conds) {
+		t.Error("expected false when A is False and B is True")
+	}
+}
+
+// ---- ConditionFreshlyEquals ----
+
+func TestConditionFreshlyEquals_CurrentGeneration(t *testing.T) {
+	rule := NewPhaseRule("JustReady", ConditionsAll(
+		ConditionFreshlyEquals("Ready", 4, metav1.ConditionTrue),
+	))
+	conds := []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 4}}
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true when condition was set at the current generation")
+	}
+	conds[0].Status = metav1.ConditionFalse
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when status does not match, even at the current generation")
+	}
+}
+
+func TestConditionFreshlyEquals_PriorGeneration(t *testing.T) {
+	rule := NewPhaseRule("JustReady", ConditionsAll(
+		ConditionFreshlyEquals("Ready", 4, metav1.ConditionTrue),
+	))
+	conds := []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 3}}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when condition was set at a prior generation")
+	}
+	if rule.Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected false when condition is missing")
+	}
+}
+
+// ---- ConditionFresh ----
+
+func TestConditionFresh(t *testing.T) {
+	rule := NewPhaseRule("Ready", ConditionsAll(
+		ConditionEquals("Available", metav1.ConditionTrue),
+		ConditionFresh("Available", 4),
+	))
+
+	for _, tt := range []struct {
+		observed int64
+		want     bool
+	}{
+		{3, false},
+		{4, true},
+		{5, true},
+	} {
+		conds := []metav1.Condition{{Type: "Available", Status: metav1.ConditionTrue, ObservedGeneration: tt.observed}}
+		if got := rule.Satisfies(&conds); got != tt.want {
+			t.Errorf("Satisfies() = %v observed at generation %d, want %v", got, tt.observed, tt.want)
+		}
+	}
+
+	conds := []metav1.Condition{{Type: "Available", Status: metav1.ConditionFalse, ObservedGeneration: 4}}
+	if got := ConditionFresh("Available", 4).Matches(&conds); got != MatcherMatched {
+		t.Errorf("Matches() = %v, want %v whatever the status", got, MatcherMatched)
+	}
+	if got := ConditionFresh("Available", 4).Matches(&[]metav1.Condition{}); got != MatcherUnknown {
+		t.Errorf("Matches() = %v, want %v when condition is missing", got, MatcherUnknown)
+	}
+}
+
+// ---- ConditionWithinGenerations ----
+
+func TestConditionWithinGenerations_Window(t *testing.T) {
+	rule := NewPhaseRule("Rolling", ConditionsAll(
+		ConditionWithinGenerations("Ready", 10, 2, metav1.ConditionTrue),
+	))
+
+	tests := []struct {
+		name     string
+		observed int64
+		want     bool
+	}{
+		{"current generation", 10, true},
+		{"within the window", 9, true},
+		{"exactly at the window edge", 8, true},
+		{"beyond the window", 7, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conds := []metav1.Condition{{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: tt.observed}}
+			if got := rule.Satisfies(&conds); got != tt.want {
+				t.Errorf("Satisfies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionWithinGenerations_StatusAndMissing(t *testing.T) {
+	rule := NewPhaseRule("Rolling", ConditionsAll(
+		ConditionWithinGenerations("Ready", 3, 0, metav1.ConditionTrue),
+	))
+	conds := []metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse, ObservedGeneration: 3}}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when status does not match")
+	}
+	if rule.Satisfies(&[]metav1.Condition{}) {
+		t.Error("expected false when condition is missing")
+	}
+
+	// conditions lagging the object's generation together don't match
+	conds = []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+		{Type: "Progressing", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+	}
+	if rule.Satisfies(&conds) {
+		t.Error("expected false when every condition lags the generation")
+	}
+}
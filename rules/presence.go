@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+type conditionPresenceMatcher struct {
+	condition string
+	missing   bool
+}
+
+var _ ConditionMatcher = (*conditionPresenceMatcher)(nil)
+
+// Matches is never MatcherUnknown for present conditions, a missing condition is what the matcher looks for.
+func (m *conditionPresenceMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+// matchesMissing counts the Unknown conditions a phase rule stands in for missing ones as missing.
+func (m *conditionPresenceMatcher) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	if conditions == nil {
+		return MatcherUnknown
+	}
+
+	present := false
+
+	for _, condition := range *conditions {
+		if condition.Type == m.condition && !missing.Has(condition.Type) {
+			present = true
+			break
+		}
+	}
+
+	if present != m.missing {
+		return MatcherMatched
+	}
+
+	return MatcherNotMatched
+}
+
+func (m *conditionPresenceMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New(m.condition)
+}
+
+// ConditionExists returns a matcher for a condition type that is present, whatever its status,
+// e.g. ConditionsAny over the condition types a controller sets, to leave an "Initializing" phase.
+func ConditionExists(condition string) ConditionMatcher {
+	return &conditionPresenceMatcher{
+		condition: condition,
+	}
+}
+
+// ConditionMissing returns a matcher for a condition type that is absent, the complement of ConditionExists.
+// Unlike every other matcher it matches a missing condition, rather than evaluating it as Unknown.
+func ConditionMissing(condition string) ConditionMatcher {
+	return &conditionPresenceMatcher{
+		condition: condition,
+		missing:   true,
+	}
+}
+
+type conditionAllPresentMatcher struct {
+	statuses []metav1.ConditionStatus
+}
+
+var _ ConditionMatcher = (*conditionAllPresentMatcher)(nil)
+
+// Matches is MatcherNotMatched for an empty list of conditions, there is nothing to be green.
+func (m *conditionAllPresentMatcher) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+// matchesMissing skips the Unknown conditions a phase rule stands in for missing ones, they aren't present.
+func (m *conditionAllPresentMatcher) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	if conditions == nil {
+		return MatcherUnknown
+	}
+
+	result := MatcherNotMatched
+
+	for _, condition := range *conditions {
+		if missing.Has(condition.Type) {
+			continue
+		}
+
+		if !slices.Contains(m.statuses, condition.Status) {
+			return MatcherNotMatched
+		}
+
+		result = MatcherMatched
+	}
+
+	return result
+}
+
+// ConditionTypes returns an empty set, the matcher reads whichever conditions are present.
+func (m *conditionAllPresentMatcher) ConditionTypes() sets.Set[string] {
+	return sets.New[string]()
+}
+
+// AllPresentEqual returns a matcher for every condition present having one of the given statuses, with at least one
+// present, e.g. AllPresentEqual(metav1.ConditionTrue) for "Ready when everything is green" across objects whose
+// condition types aren't known ahead of time. Unlike ConditionsAll it doesn't enumerate condition types.
+func AllPresentEqual(statuses ...metav1.ConditionStatus) ConditionMatcher {
+	return &conditionAllPresentMatcher{
+		statuses: statuses,
+	}
+}
@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionPresence(t *testing.T) {
+	tests := []struct {
+		name        string
+		conds       *[]metav1.Condition
+		wantExists  MatchResult
+		wantMissing MatchResult
+	}{
+		{"present", &[]metav1.Condition{{Type: "Ready", Status: metav1.ConditionFalse}}, MatcherMatched, MatcherNotMatched},
+		{"present and Unknown", &[]metav1.Condition{{Type: "Ready", Status: metav1.ConditionUnknown}}, MatcherMatched, MatcherNotMatched},
+		{"absent", &[]metav1.Condition{{Type: "Synced", Status: metav1.ConditionTrue}}, MatcherNotMatched, MatcherMatched},
+		{"no conditions", &[]metav1.Condition{}, MatcherNotMatched, MatcherMatched},
+		{"nil conditions", nil, MatcherUnknown, MatcherUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ConditionExists("Ready").Matches(tt.conds); got != tt.wantExists {
+				t.Errorf("ConditionExists().Matches() = %v, want %v", got, tt.wantExists)
+			}
+			if got := ConditionMissing("Ready").Matches(tt.conds); got != tt.wantMissing {
+				t.Errorf("ConditionMissing().Matches() = %v, want %v", got, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestConditionPresence_InRules(t *testing.T) {
+	initializing := NewPhaseRule("Initializing", ConditionsAll(ConditionMissing("Available"), ConditionMissing("Progressing")))
+	started := NewPhaseRule("Started", ConditionsAny(ConditionExists("Available"), ConditionExists("Progressing")))
+	unknown := NewPhaseRule("Waiting", ConditionEquals("Available", metav1.ConditionUnknown))
+
+	tests := []struct {
+		name                                       string
+		conds                                      []metav1.Condition
+		wantInitializing, wantStarted, wantUnknown bool
+	}{
+		{"nothing set", []metav1.Condition{}, true, false, true},
+		{"one set", []metav1.Condition{{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "Rolling"}}, false, true, true},
+		{"set Unknown", []metav1.Condition{{Type: "Available", Status: metav1.ConditionUnknown, Reason: "Probing"}}, false, true, true},
+		{"set Unknown, whatever its message", []metav1.Condition{{Type: "Available", Status: metav1.ConditionUnknown, Message: "condition is missing, evaluated as Unknown"}}, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := initializing.Satisfies(&tt.conds); got != tt.wantInitializing {
+				t.Errorf("Initializing Satisfies() = %v, want %v", got, tt.wantInitializing)
+			}
+			if got := started.Satisfies(&tt.conds); got != tt.wantStarted {
+				t.Errorf("Started Satisfies() = %v, want %v", got, tt.wantStarted)
+			}
+			if got := unknown.Satisfies(&tt.conds); got != tt.wantUnknown {
+				t.Errorf("missing conditions no longer evaluate as Unknown: Satisfies() = %v, want %v", got, tt.wantUnknown)
+			}
+		})
+	}
+}
+
+func TestAllPresentEqual(t *testing.T) {
+	green := NewPhaseRule("Ready", ConditionsAll(AllPresentEqual(metav1.ConditionTrue), ConditionMissing("Paused")))
+
+	tests := []struct {
+		name  string
+		conds *[]metav1.Condition
+		want  MatchResult
+		ready bool
+	}{
+		{"all True", &[]metav1.Condition{{Type: "A", Status: metav1.ConditionTrue}, {Type: "B", Status: metav1.ConditionTrue}}, MatcherMatched, true},
+		{"one False", &[]metav1.Condition{{Type: "A", Status: metav1.ConditionTrue}, {Type: "B", Status: metav1.ConditionFalse}}, MatcherNotMatched, false},
+		{"one Unknown", &[]metav1.Condition{{Type: "A", Status: metav1.ConditionUnknown}}, MatcherNotMatched, false},
+		{"no conditions", &[]metav1.Condition{}, MatcherNotMatched, false},
+		{"nil conditions", nil, MatcherUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AllPresentEqual(metav1.ConditionTrue).Matches(tt.conds); got != tt.want {
+				t.Errorf("AllPresentEqual().Matches() = %v, want %v", got, tt.want)
+			}
+			// the Unknown condition standing in for the missing Paused isn't present
+			if got := green.Satisfies(tt.conds); got != tt.ready {
+				t.Errorf("Satisfies() = %v, want %v", got, tt.ready)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package rules
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// Registry holds the phase rules of several kinds, keyed by their GroupVersionKind.
+// It is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[schema.GroupVersionKind][]PhaseRule
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		rules: make(map[schema.GroupVersionKind][]PhaseRule),
+	}
+}
+
+// Register sets the ordered phase rules for a kind, replacing any rules registered before.
+func (r *Registry) Register(gvk schema.GroupVersionKind, rules ...PhaseRule) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[gvk] = rules
+}
+
+// Lookup returns the phase rules registered for a kind.
+func (r *Registry) Lookup(gvk schema.GroupVersionKind) ([]PhaseRule, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules, ok := r.rules[gvk]
+	return rules, ok
+}
+
+// ComputePhase evaluates the conditions against the rules of a kind, first matching rule wins.
+// Returns PhaseUnknown if no rule matches or no rules are registered for the kind.
+func (r *Registry) ComputePhase(gvk schema.GroupVersionKind, conditions *[]metav1.Condition) string {
+	rules, _ := r.Lookup(gvk)
+
+	return NewPhaseComputer(rules...).Compute(conditions)
+}
+
+// ConditionTypes returns the condition types any of the rules depends on, e.g. to build watch predicates
+// that ignore changes to other conditions.
+func ConditionTypes(rules []PhaseRule) sets.Set[string] {
+	types := sets.New[string]()
+
+	for _, rule := range rules {
+		types.DestructiveUnion(rule.ConditionTypes())
+	}
+
+	return types
+}
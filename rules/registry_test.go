@@ -0,0 +1,58 @@
+package rules
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var (
+	backupGVK = schema.GroupVersionKind{Group: "airlock.cloud.rocket.chat", Version: "v1alpha1", Kind: "MongoDBBackup"}
+	storeGVK  = schema.GroupVersionKind{Group: "airlock.cloud.rocket.chat", Version: "v1alpha1", Kind: "MongoDBBackupStore"}
+)
+
+func TestRegistry_ComputePhasePerKind(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(backupGVK,
+		NewPhaseRule("Completed", ConditionsAll(ConditionEquals("JobCompleted", metav1.ConditionTrue))),
+		NewPhaseRule("Running", ConditionsAll(ConditionEquals("JobScheduled", metav1.ConditionTrue))),
+	)
+	registry.Register(storeGVK,
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("JobScheduled", metav1.ConditionTrue))),
+	)
+
+	conds := []metav1.Condition{cond("JobScheduled", metav1.ConditionTrue)}
+
+	if got := registry.ComputePhase(backupGVK, &conds); got != "Running" {
+		t.Errorf("ComputePhase(backup) = %q, want %q", got, "Running")
+	}
+	if got := registry.ComputePhase(storeGVK, &conds); got != "Ready" {
+		t.Errorf("ComputePhase(store) = %q, want %q", got, "Ready")
+	}
+
+	conds = append(conds, cond("JobCompleted", metav1.ConditionTrue))
+	if got := registry.ComputePhase(backupGVK, &conds); got != "Completed" {
+		t.Errorf("ComputePhase(backup) = %q, want %q (first matching rule wins)", got, "Completed")
+	}
+}
+
+func TestRegistry_Lookup(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(backupGVK, NewPhaseRule("Ready", ConditionsAll()))
+
+	if rules, ok := registry.Lookup(backupGVK); !ok || len(rules) != 1 {
+		t.Errorf("Lookup(backup) = %v, %v, want one rule", rules, ok)
+	}
+	if _, ok := registry.Lookup(storeGVK); ok {
+		t.Error("expected Lookup to report an unregistered kind as missing")
+	}
+}
+
+func TestRegistry_UnregisteredKind(t *testing.T) {
+	registry := NewRegistry()
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue)}
+	if got := registry.ComputePhase(storeGVK, &conds); got != PhaseUnknown {
+		t.Errorf("ComputePhase() = %q, want %q", got, PhaseUnknown)
+	}
+}
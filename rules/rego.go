@@ -0,0 +1,339 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// RegoPackage is the package of the Rego module generated by ToRego.
+const RegoPackage = "phaserules"
+
+// ToRego translates phase rules into a Rego module for Open Policy Agent that computes the same phase as
+// evaluating the rules by priority, then in order. Query data.phaserules.phase with the conditions as input,
+// {"conditions": [...]}, using the JSON field names of metav1.Condition. Missing input conditions yield
+// PhaseUnknown, like nil conditions.
+//
+// Supported are rules built with NewPhaseRule, NewPhaseRuleWithPriority, Negate and CombineRules over
+// ConditionEquals, ConditionNotEquals, ConditionFreshlyEquals, ConditionFresh, ConditionWithinGenerations,
+// ConditionReasonIs, ConditionReasonEquals, ConditionMessageContains, ConditionMessageMatches, ConditionExists,
+// ConditionMissing, AllPresentEqual, ConditionsAll, ConditionsAny, ConditionsAnyResolved, ConditionsAtLeast,
+// ConditionsWeighted and ConditionsDominant. Any other rule or matcher, including the ConditionPrefix matchers and
+// custom implementations, returns an error.
+func ToRego(rules []PhaseRule) (string, error) {
+	g := &regoGenerator{referenced: sets.New[string]()}
+
+	rules = NewPhaseComputer(rules...).Rules()
+
+	ruleNames := make([]string, 0, len(rules))
+
+	for _, rule := range rules {
+		name, err := g.rule(rule)
+		if err != nil {
+			return "", err
+		}
+
+		ruleNames = append(ruleNames, name)
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "package %s\n\nimport rego.v1\n\n", RegoPackage)
+
+	fmt.Fprintf(&b, "default phase := %s\n", regoString(PhaseUnknown))
+
+	for i, name := range ruleNames {
+		if i == 0 {
+			fmt.Fprintf(&b, "\nphase := %s if {\n\t%s\n}", regoString(rules[i].Phase()), name)
+		} else {
+			fmt.Fprintf(&b, " else := %s if {\n\t%s\n}", regoString(rules[i].Phase()), name)
+		}
+	}
+
+	if len(ruleNames) > 0 {
+		b.WriteString("\n")
+	}
+
+	// condition types referenced by the rules but missing from the input are evaluated as Unknown
+	referenced := make([]string, 0, g.referenced.Len())
+	for conditionType := range g.referenced {
+		referenced = append(referenced, regoString(conditionType))
+	}
+	slices.Sort(referenced)
+
+	fmt.Fprintf(&b, "\nreferenced := %s\n", regoSet(referenced))
+	b.WriteString(`
+conditions contains condition if {
+	some condition in input.conditions
+}
+
+conditions contains {"type": t, "status": "Unknown"} if {
+	some t in referenced
+	not present(t)
+}
+
+present(t) if {
+	some condition in input.conditions
+	condition.type == t
+}
+`)
+
+	for _, helper := range g.helpers {
+		b.WriteString("\n")
+		b.WriteString(helper)
+	}
+
+	return b.String(), nil
+}
+
+type regoGenerator struct {
+	helpers    []string
+	referenced sets.Set[string]
+	rules      int
+	matchers   int
+}
+
+func (g *regoGenerator) rule(rule PhaseRule) (string, error) {
+	name := fmt.Sprintf("rule_%d", g.rules)
+	g.rules++
+
+	// the rule is satisfied if any of the bodies is
+	var bodies []string
+
+	switch r := rule.(type) {
+	case *phaseRuleSimple:
+		matcher, err := g.matcher(r.matcher)
+		if err != nil {
+			return "", err
+		}
+
+		bodies = append(bodies, matcher)
+	case *phaseRuleNegated:
+		base, err := g.rule(r.base)
+		if err != nil {
+			return "", err
+		}
+
+		bodies = append(bodies, "not "+base)
+	case *phaseRuleCombined:
+		for _, combined := range r.rules {
+			combinedName, err := g.rule(combined)
+			if err != nil {
+				return "", err
+			}
+
+			bodies = append(bodies, combinedName)
+		}
+	default:
+		return "", fmt.Errorf("rule for phase %q: unsupported rule type %T", rule.Phase(), rule)
+	}
+
+	helper := fmt.Sprintf("default %s := false\n", name)
+	for _, body := range bodies {
+		helper += fmt.Sprintf("\n%s if {\n\tis_array(input.conditions)\n\t%s\n}\n", name, body)
+	}
+
+	g.helpers = append(g.helpers, helper)
+
+	return name, nil
+}
+
+func (g *regoGenerator) matcher(matcher ConditionMatcher) (string, error) {
+	name := fmt.Sprintf("matcher_%d", g.matchers)
+	g.matchers++
+
+	helper := fmt.Sprintf("default %s := false\n", name)
+
+	if resolved, ok := matcher.(*conditionMatcherAnyResolved); ok {
+		// the resolution only affects Resolve, it matches like ConditionsAny
+		matcher = &resolved.conditionMatcherAny
+	}
+
+	switch m := matcher.(type) {
+	case *conditionEqualsMatcher:
+		g.referenced.Insert(m.condition)
+
+		helper += fmt.Sprintf("\n%s if {\n\tsome condition in conditions\n\tcondition.type == %s\n\tcondition.status in %s\n}\n",
+			name, regoString(m.condition), regoStatuses(m.statuses))
+	case *conditionNotEqualsMatcher:
+		g.referenced.Insert(m.condition)
+
+		// the Unknown conditions standing in for missing ones don't match
+		helper += fmt.Sprintf("\n%s if {\n\tpresent(%s)\n\tsome condition in conditions\n\tcondition.type == %s\n\tnot condition.status in %s\n}\n",
+			name, regoString(m.condition), regoString(m.condition), regoStatuses(m.statuses))
+	case *conditionFreshlyEqualsMatcher:
+		g.referenced.Insert(m.condition)
+
+		helper += fmt.Sprintf("\n%s if {\n\tsome condition in conditions\n\tcondition.type == %s\n\tcondition.status in %s\n\tobject.get(condition, \"observedGeneration\", 0) == %d\n}\n",
+			name, regoString(m.condition), regoStatuses(m.statuses), m.generation)
+	case *conditionFreshMatcher:
+		g.referenced.Insert(m.condition)
+
+		helper += fmt.Sprintf("\n%s if {\n\tsome condition in conditions\n\tcondition.type == %s\n\tobject.get(condition, \"observedGeneration\", 0) >= %d\n}\n",
+			name, regoString(m.condition), m.generation)
+	case *conditionWithinGenerationsMatcher:
+		g.referenced.Insert(m.condition)
+
+		helper += fmt.Sprintf("\n%s if {\n\tsome condition in conditions\n\tcondition.type == %s\n\tcondition.status in %s\n\tobject.get(condition, \"observedGeneration\", 0) >= %d\n}\n",
+			name, regoString(m.condition), regoStatuses(m.statuses), m.generation-m.generations)
+	case *conditionReasonMatcher:
+		g.referenced.Insert(m.condition)
+
+		// the Unknown conditions standing in for missing ones have no reason
+		helper += fmt.Sprintf("\n%s if {\n\tpresent(%s)\n\tsome condition in conditions\n\tcondition.type == %s\n\tobject.get(condition, \"reason\", \"\") in %s\n}\n",
+			name, regoString(m.condition), regoString(m.condition), regoReasons(m.reasons))
+	case *conditionReasonEqualsMatcher:
+		g.referenced.Insert(m.condition)
+
+		helper += fmt.Sprintf("\n%s if {\n\tpresent(%s)\n\tsome condition in conditions\n\tcondition.type == %s\n\tcondition.status == %s\n\tobject.get(condition, \"reason\", \"\") in %s\n}\n",
+			name, regoString(m.condition), regoString(m.condition), regoString(string(m.status)), regoReasons(m.reasons))
+	case *conditionMessageMatcher:
+		g.referenced.Insert(m.condition)
+
+		// the Unknown conditions standing in for missing ones have no message
+		test := fmt.Sprintf("contains(object.get(condition, \"message\", \"\"), %s)", regoString(m.substr))
+		if m.re != nil {
+			test = fmt.Sprintf("regex.match(%s, object.get(condition, \"message\", \"\"))", regoString(m.re.String()))
+		}
+
+		statuses := ""
+		if len(m.statuses) > 0 {
+			statuses = fmt.Sprintf("\tcondition.status in %s\n", regoStatuses(m.statuses))
+		}
+
+		helper += fmt.Sprintf("\n%s if {\n\tpresent(%s)\n\tsome condition in conditions\n\tcondition.type == %[2]s\n%s\t%s\n}\n",
+			name, regoString(m.condition), statuses, test)
+	case *conditionPresenceMatcher:
+		g.referenced.Insert(m.condition)
+
+		// presence is of the input conditions, not of the Unknown ones standing in for missing conditions
+		negation := ""
+		if m.missing {
+			negation = "not "
+		}
+
+		helper += fmt.Sprintf("\n%s if {\n\t%spresent(%s)\n}\n", name, negation, regoString(m.condition))
+	case *conditionAllPresentMatcher:
+		helper += fmt.Sprintf("\n%s if {\n\tcount(input.conditions) > 0\n\tevery condition in input.conditions {\n\t\tcondition.status in %s\n\t}\n}\n",
+			name, regoStatuses(m.statuses))
+	case *conditionMatcherAll:
+		children := make([]string, 0, len(m.matcherReferences))
+
+		for _, child := range m.matcherReferences {
+			childName, err := g.matcher(child)
+			if err != nil {
+				return "", err
+			}
+
+			children = append(children, childName)
+		}
+
+		if len(children) == 0 {
+			children = append(children, "true")
+		}
+
+		helper += fmt.Sprintf("\n%s if {\n\t%s\n}\n", name, strings.Join(children, "\n\t"))
+	case *conditionMatcherAny:
+		// one rule body per alternative, Rego ORs them; no alternative leaves the default, false
+		for _, child := range m.matcherReferences {
+			childName, err := g.matcher(child)
+			if err != nil {
+				return "", err
+			}
+
+			helper += fmt.Sprintf("\n%s if {\n\t%s\n}\n", name, childName)
+		}
+	case *conditionMatcherAtLeast:
+		children := make([]string, 0, len(m.matcherReferences))
+
+		for _, child := range m.matcherReferences {
+			childName, err := g.matcher(child)
+			if err != nil {
+				return "", err
+			}
+
+			children = append(children, childName)
+		}
+
+		helper += fmt.Sprintf("\n%s if {\n\tcount([m | some m in [%s]; m]) >= %d\n}\n", name, strings.Join(children, ", "), m.n)
+	case *conditionMatcherWeighted:
+		pairs := make([]string, 0, len(m.weighted))
+
+		for _, weighted := range m.weighted {
+			childName, err := g.matcher(weighted.Matcher)
+			if err != nil {
+				return "", err
+			}
+
+			pairs = append(pairs, fmt.Sprintf("[%s, %s]", childName, regoNumber(weighted.Weight)))
+		}
+
+		helper += fmt.Sprintf("\n%s if {\n\tsum([pair[1] | some pair in [%s]; pair[0]]) >= %s\n}\n", name, strings.Join(pairs, ", "), regoNumber(m.threshold))
+	case *conditionMatcherDominant:
+		dominant, err := g.matcher(m.dominant)
+		if err != nil {
+			return "", err
+		}
+
+		rest, err := g.matcher(m.rest)
+		if err != nil {
+			return "", err
+		}
+
+		if m.effect == DominantSatisfies {
+			helper += fmt.Sprintf("\n%s if {\n\t%s\n}\n", name, dominant)
+		}
+
+		helper += fmt.Sprintf("\n%s if {\n\tnot %s\n\t%s\n}\n", name, dominant, rest)
+	default:
+		return "", fmt.Errorf("unsupported matcher type %T", matcher)
+	}
+
+	g.helpers = append(g.helpers, helper)
+
+	return name, nil
+}
+
+func regoStatuses(statuses []metav1.ConditionStatus) string {
+	quoted := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		quoted = append(quoted, regoString(string(status)))
+	}
+
+	return regoSet(quoted)
+}
+
+func regoReasons(reasons []string) string {
+	quoted := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		quoted = append(quoted, regoString(reason))
+	}
+
+	return regoSet(quoted)
+}
+
+// regoSet returns a Rego set of already quoted items, {} would be an empty object.
+func regoSet(items []string) string {
+	if len(items) == 0 {
+		return "set()"
+	}
+
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+// regoNumber formats f as a Rego number, Rego numbers follow JSON.
+func regoNumber(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// regoString quotes s as a Rego string, Rego strings follow JSON escaping.
+func regoString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
@@ -0,0 +1,185 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToRego_PhaseChain(t *testing.T) {
+	module, err := ToRego([]PhaseRule{
+		NewPhaseRule("Ready", ConditionsAll(
+			ConditionEquals("A", metav1.ConditionTrue),
+			ConditionReasonIs("B", "Done"),
+		)),
+		NewPhaseRule("Failed", ConditionsAny(
+			ConditionEquals("A", metav1.ConditionFalse),
+			ConditionFreshlyEquals("B", 2, metav1.ConditionFalse),
+		)),
+		NewPhaseRule("Degraded", ConditionNotEquals("C", metav1.ConditionTrue)),
+	})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"package phaserules\n",
+		`default phase := "Unknown"`,
+		"phase := \"Ready\" if {\n\trule_0\n} else := \"Failed\" if {\n\trule_1\n}",
+		`referenced := {"A", "B", "C"}`,
+		"condition.type == \"C\"\n\tnot condition.status in {\"True\"}",
+		"condition.type == \"A\"\n\tcondition.status in {\"True\"}",
+		`object.get(condition, "reason", "") in {"Done"}`,
+		`object.get(condition, "observedGeneration", 0) == 2`,
+	} {
+		if !strings.Contains(module, want) {
+			t.Errorf("generated module is missing %q:\n%s", want, module)
+		}
+	}
+}
+
+func TestToRego_Negate(t *testing.T) {
+	ready := NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue)))
+
+	module, err := ToRego([]PhaseRule{ready, Negate(ready, "NotReady")})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	if !strings.Contains(module, "is_array(input.conditions)\n\tnot rule_2\n") {
+		t.Errorf("generated module does not negate the base rule:\n%s", module)
+	}
+}
+
+func TestToRego_Priority(t *testing.T) {
+	module, err := ToRego([]PhaseRule{
+		NewPhaseRule("Ready", ConditionEquals("A", metav1.ConditionTrue)),
+		NewPhaseRuleWithPriority("Deleting", 1, ConditionEquals("D", metav1.ConditionTrue)),
+	})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	if want := "phase := \"Deleting\" if {\n\trule_0\n} else := \"Ready\" if {\n\trule_1\n}"; !strings.Contains(module, want) {
+		t.Errorf("generated module does not evaluate the higher priority rule first:\n%s", module)
+	}
+}
+
+func TestToRego_ReasonEquals(t *testing.T) {
+	module, err := ToRego([]PhaseRule{
+		NewPhaseRule("BackingOff", ConditionReasonEquals("Ready", metav1.ConditionFalse, "Backoff")),
+	})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	if want := "condition.type == \"Ready\"\n\tcondition.status == \"False\"\n\tobject.get(condition, \"reason\", \"\") in {\"Backoff\"}"; !strings.Contains(module, want) {
+		t.Errorf("generated module is missing %q:\n%s", want, module)
+	}
+}
+
+func TestToRego_Fresh(t *testing.T) {
+	module, err := ToRego([]PhaseRule{NewPhaseRule("Ready", ConditionFresh("Available", 3))})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	if want := `object.get(condition, "observedGeneration", 0) >= 3`; !strings.Contains(module, want) {
+		t.Errorf("generated module is missing %q:\n%s", want, module)
+	}
+}
+
+func TestToRego_Presence(t *testing.T) {
+	module, err := ToRego([]PhaseRule{
+		NewPhaseRule("Initializing", ConditionMissing("Available")),
+		NewPhaseRule("Started", ConditionExists("Available")),
+	})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	for _, want := range []string{"\tnot present(\"Available\")\n", "\tpresent(\"Available\")\n"} {
+		if !strings.Contains(module, want) {
+			t.Errorf("generated module is missing %q:\n%s", want, module)
+		}
+	}
+}
+
+func TestToRego_AllPresentEqual(t *testing.T) {
+	module, err := ToRego([]PhaseRule{NewPhaseRule("Ready", AllPresentEqual(metav1.ConditionTrue))})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	if want := "\tevery condition in input.conditions {\n\t\tcondition.status in {\"True\"}\n\t}\n"; !strings.Contains(module, want) {
+		t.Errorf("generated module is missing %q:\n%s", want, module)
+	}
+}
+
+func TestToRego_CombineRules(t *testing.T) {
+	module, err := ToRego([]PhaseRule{CombineRules("Ready",
+		NewPhaseRule("Serving", ConditionEquals("A", metav1.ConditionTrue)),
+		NewPhaseRule("Idle", ConditionEquals("B", metav1.ConditionTrue)),
+	)})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	for _, want := range []string{"rule_0 if {\n\tis_array(input.conditions)\n\trule_1\n}\n", "rule_0 if {\n\tis_array(input.conditions)\n\trule_2\n}\n"} {
+		if !strings.Contains(module, want) {
+			t.Errorf("generated module is missing %q:\n%s", want, module)
+		}
+	}
+}
+
+func TestToRego_ConditionMessage(t *testing.T) {
+	module, err := ToRego([]PhaseRule{NewPhaseRule("QuotaExceeded", ConditionsAny(
+		ConditionMessageContains("Ready", "quota exceeded", metav1.ConditionFalse),
+		ConditionMessageMatches("Ready", regexp.MustCompile(`retry \d+`)),
+	))})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"\tcondition.status in {\"False\"}\n\tcontains(object.get(condition, \"message\", \"\"), \"quota exceeded\")\n",
+		"\tregex.match(\"retry \\\\d+\", object.get(condition, \"message\", \"\"))\n",
+	} {
+		if !strings.Contains(module, want) {
+			t.Errorf("generated module is missing %q:\n%s", want, module)
+		}
+	}
+}
+
+func TestToRego_Weighted(t *testing.T) {
+	module, err := ToRego([]PhaseRule{NewPhaseRule("Healthy", ConditionsWeighted(2.5,
+		WeightedMatcher{Matcher: ConditionEquals("A", metav1.ConditionTrue), Weight: 2},
+		WeightedMatcher{Matcher: ConditionEquals("B", metav1.ConditionTrue), Weight: 0.5},
+	))})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	if want := "sum([pair[1] | some pair in [[matcher_1, 2], [matcher_2, 0.5]]; pair[0]]) >= 2.5"; !strings.Contains(module, want) {
+		t.Errorf("generated module is missing %q:\n%s", want, module)
+	}
+}
+
+func TestToRego_Unsupported(t *testing.T) {
+	if _, err := ToRego([]PhaseRule{NewPhaseRule("Settled", ConditionPrefixUniform("dependency/"))}); err == nil {
+		t.Error("expected an error for ConditionPrefixUniform")
+	}
+}
+
+func TestToRego_WithinGenerations(t *testing.T) {
+	module, err := ToRego([]PhaseRule{NewPhaseRule("Rolling", ConditionWithinGenerations("A", 10, 2, metav1.ConditionTrue))})
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	if want := `object.get(condition, "observedGeneration", 0) >= 8`; !strings.Contains(module, want) {
+		t.Errorf("generated module is missing %q:\n%s", want, module)
+	}
+}
@@ -0,0 +1,3 @@
+// Package regotest checks the Rego that rules.ToRego generates against OPA. It is a module of its own so that the
+// library doesn't require OPA and its dependencies.
+package regotest
@@ -0,0 +1,126 @@
+package regotest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/rules"
+)
+
+func TestToRego_MatchesPhaseComputer(t *testing.T) {
+	phaseRules := []rules.PhaseRule{
+		rules.NewPhaseRuleWithPriority("Deleting", 1, rules.ConditionExists("Deleting")),
+		rules.NewPhaseRule("Ready", rules.ConditionsAll(
+			rules.ConditionEquals("A", metav1.ConditionTrue),
+			rules.ConditionFreshlyEquals("B", 2, metav1.ConditionTrue),
+		)),
+		rules.CombineRules("Failed",
+			rules.NewPhaseRule("Failed", rules.ConditionReasonEquals("A", metav1.ConditionFalse, "Crashed")),
+			rules.NewPhaseRule("Failed", rules.ConditionMessageContains("B", "quota exceeded", metav1.ConditionFalse)),
+		),
+		rules.NewPhaseRule("Progressing", rules.ConditionsAtLeast(2,
+			rules.ConditionEquals("A", metav1.ConditionTrue),
+			rules.ConditionNotEquals("B", metav1.ConditionFalse),
+			rules.ConditionMissing("C"),
+		)),
+		rules.Negate(rules.NewPhaseRule("Settled", rules.ConditionsAny(
+			rules.ConditionEquals("A", metav1.ConditionFalse),
+			rules.ConditionEquals("C", metav1.ConditionTrue),
+		)), "Pending"),
+	}
+
+	module, err := rules.ToRego(phaseRules)
+	if err != nil {
+		t.Fatalf("ToRego() error = %v", err)
+	}
+
+	query, err := rego.New(rego.Query("data.phaserules.phase"), rego.Module("phaserules.rego", module)).PrepareForEval(context.Background())
+	if err != nil {
+		t.Fatalf("PrepareForEval() error = %v\n%s", err, module)
+	}
+
+	computer := rules.NewPhaseComputer(phaseRules...)
+
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+	}{
+		{"no conditions", nil},
+		{"ready", []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+			{Type: "B", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+		}},
+		{"ready but stale", []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+			{Type: "B", Status: metav1.ConditionTrue, ObservedGeneration: 1},
+		}},
+		{"deleting", []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+			{Type: "B", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+			{Type: "Deleting", Status: metav1.ConditionFalse},
+		}},
+		{"crashed", []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionFalse, Reason: "Crashed"},
+		}},
+		{"quota exceeded", []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionUnknown},
+			{Type: "B", Status: metav1.ConditionFalse, Message: "pods: quota exceeded"},
+		}},
+		{"false for another reason", []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionFalse, Reason: "Backoff"},
+			{Type: "C", Status: metav1.ConditionFalse},
+		}},
+		{"progressing", []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionTrue},
+			{Type: "B", Status: metav1.ConditionUnknown},
+		}},
+		{"only C", []metav1.Condition{
+			{Type: "C", Status: metav1.ConditionFalse},
+		}},
+		{"settled", []metav1.Condition{
+			{Type: "A", Status: metav1.ConditionUnknown},
+			{Type: "C", Status: metav1.ConditionTrue},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := map[string]any{"conditions": toRegoInput(t, tt.conditions)}
+
+			results, err := query.Eval(context.Background(), rego.EvalInput(input))
+			if err != nil {
+				t.Fatalf("Eval() error = %v", err)
+			}
+			if len(results) != 1 || len(results[0].Expressions) != 1 {
+				t.Fatalf("Eval() = %v, want a single phase", results)
+			}
+
+			conditions := tt.conditions
+			if got, want := results[0].Expressions[0].Value, computer.Compute(&conditions); got != want {
+				t.Errorf("Rego phase = %v, PhaseComputer phase = %q", got, want)
+			}
+		})
+	}
+}
+
+// toRegoInput converts conditions to their JSON form, as OPA receives them.
+func toRegoInput(t *testing.T, conditions []metav1.Condition) []any {
+	t.Helper()
+
+	// a nil slice would marshal to null, OPA is given an empty list
+	data, err := json.Marshal(append([]metav1.Condition{}, conditions...))
+	if err != nil {
+		t.Fatalf("marshal conditions: %v", err)
+	}
+
+	input := []any{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		t.Fatalf("unmarshal conditions: %v", err)
+	}
+
+	return input
+}
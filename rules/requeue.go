@@ -0,0 +1,34 @@
+package rules
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RequeuePolicy maps phases to how soon a controller should requeue an object in that phase,
+// e.g. short intervals for transitional phases and long ones for stable phases.
+type RequeuePolicy struct {
+	// Intervals holds the requeue interval per phase.
+	Intervals map[string]time.Duration
+
+	// Default is used for phases without an interval, PhaseUnknown included.
+	Default time.Duration
+}
+
+// RequeueAfter returns the requeue interval for a phase.
+func (p RequeuePolicy) RequeueAfter(phase string) time.Duration {
+	if interval, ok := p.Intervals[phase]; ok {
+		return interval
+	}
+
+	return p.Default
+}
+
+// ComputePhaseWithRequeue computes the phase from the rules, first matching rule wins,
+// along with the requeue interval the policy assigns to that phase.
+func ComputePhaseWithRequeue(rules []PhaseRule, conditions *[]metav1.Condition, policy RequeuePolicy) (string, time.Duration) {
+	phase := NewPhaseComputer(rules...).Compute(conditions)
+
+	return phase, policy.RequeueAfter(phase)
+}
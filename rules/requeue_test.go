@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputePhaseWithRequeue(t *testing.T) {
+	phaseRules := []PhaseRule{
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue))),
+		NewPhaseRule("Progressing", ConditionsAll(ConditionEquals("A", metav1.ConditionUnknown))),
+	}
+	policy := RequeuePolicy{
+		Intervals: map[string]time.Duration{
+			"Ready":       10 * time.Minute,
+			"Progressing": 5 * time.Second,
+		},
+		Default: time.Minute,
+	}
+
+	tests := []struct {
+		name      string
+		conds     []metav1.Condition
+		wantPhase string
+		wantAfter time.Duration
+	}{
+		{"stable", []metav1.Condition{cond("A", metav1.ConditionTrue)}, "Ready", 10 * time.Minute},
+		{"transitioning", []metav1.Condition{cond("A", metav1.ConditionUnknown)}, "Progressing", 5 * time.Second},
+		{"missing is transitioning", []metav1.Condition{}, "Progressing", 5 * time.Second},
+		{"no rule matches", []metav1.Condition{cond("A", metav1.ConditionFalse)}, PhaseUnknown, time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase, after := ComputePhaseWithRequeue(phaseRules, &tt.conds, policy)
+			if phase != tt.wantPhase {
+				t.Errorf("phase = %q, want %q", phase, tt.wantPhase)
+			}
+			if after != tt.wantAfter {
+				t.Errorf("requeueAfter = %v, want %v", after, tt.wantAfter)
+			}
+		})
+	}
+}
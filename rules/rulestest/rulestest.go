@@ -0,0 +1,61 @@
+// Package rulestest provides assertions for testing phase rules.
+package rulestest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/rules"
+)
+
+// AssertPhase reports an error on t if rule doesn't compute expectedPhase for conditions, and returns whether it did.
+func AssertPhase(t testing.TB, rule rules.PhaseRule, conditions *[]metav1.Condition, expectedPhase string) bool {
+	t.Helper()
+
+	if got := rule.ComputePhase(conditions); got != expectedPhase {
+		t.Errorf("%s computed phase %q, want %q\nconditions: %s", Describe(rule), got, expectedPhase, FormatConditions(conditions))
+		return false
+	}
+
+	return true
+}
+
+// AssertSatisfies reports an error on t if rule's Satisfies for conditions isn't expected, and returns whether it was.
+func AssertSatisfies(t testing.TB, rule rules.PhaseRule, conditions *[]metav1.Condition, expected bool) bool {
+	t.Helper()
+
+	if got := rule.Satisfies(conditions); got != expected {
+		t.Errorf("%s satisfied = %v, want %v\nconditions: %s", Describe(rule), got, expected, FormatConditions(conditions))
+		return false
+	}
+
+	return true
+}
+
+// Describe returns a short description of rule for failure messages.
+func Describe(rule rules.PhaseRule) string {
+	return fmt.Sprintf("rule for phase %q (%T)", rule.Phase(), rule)
+}
+
+// FormatConditions formats conditions as a list of type=status pairs, with the reason when set.
+func FormatConditions(conditions *[]metav1.Condition) string {
+	if conditions == nil {
+		return "<nil>"
+	}
+
+	formatted := make([]string, 0, len(*conditions))
+
+	for _, condition := range *conditions {
+		pair := condition.Type + "=" + string(condition.Status)
+		if condition.Reason != "" {
+			pair += " (" + condition.Reason + ")"
+		}
+
+		formatted = append(formatted, pair)
+	}
+
+	return "[" + strings.Join(formatted, ", ") + "]"
+}
@@ -0,0 +1,72 @@
+package rulestest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/rules"
+)
+
+// recorder captures the failures reported to it instead of failing the test.
+type recorder struct {
+	testing.TB
+
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+var readyRule = rules.NewPhaseRule("Ready", rules.ConditionsAll(
+	rules.ConditionEquals("A", metav1.ConditionTrue),
+))
+
+func TestAssertPhase(t *testing.T) {
+	conds := []metav1.Condition{{Type: "A", Status: metav1.ConditionTrue}}
+
+	r := &recorder{TB: t}
+	if !AssertPhase(r, readyRule, &conds, "Ready") || len(r.errors) != 0 {
+		t.Errorf("expected the assertion to pass, got %v", r.errors)
+	}
+}
+
+func TestAssertPhase_Failure(t *testing.T) {
+	conds := []metav1.Condition{{Type: "A", Status: metav1.ConditionFalse, Reason: "Broken"}}
+
+	r := &recorder{TB: t}
+	if AssertPhase(r, readyRule, &conds, "Ready") || len(r.errors) != 1 {
+		t.Fatalf("expected the assertion to fail once, got %v", r.errors)
+	}
+
+	for _, want := range []string{`rule for phase "Ready"`, `computed phase "Unknown", want "Ready"`, "[A=False (Broken)]"} {
+		if !strings.Contains(r.errors[0], want) {
+			t.Errorf("failure message %q is missing %q", r.errors[0], want)
+		}
+	}
+}
+
+func TestAssertSatisfies(t *testing.T) {
+	r := &recorder{TB: t}
+	if !AssertSatisfies(r, readyRule, nil, false) || len(r.errors) != 0 {
+		t.Errorf("expected the assertion to pass, got %v", r.errors)
+	}
+}
+
+func TestAssertSatisfies_Failure(t *testing.T) {
+	conds := []metav1.Condition{}
+
+	r := &recorder{TB: t}
+	if AssertSatisfies(r, readyRule, &conds, true) || len(r.errors) != 1 {
+		t.Fatalf("expected the assertion to fail once, got %v", r.errors)
+	}
+
+	if want := "satisfied = false, want true\nconditions: []"; !strings.Contains(r.errors[0], want) {
+		t.Errorf("failure message %q is missing %q", r.errors[0], want)
+	}
+}
@@ -0,0 +1,403 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// RuleSpec is the declarative form of a phase rule, for rules kept in YAML or JSON, e.g. in a ConfigMap:
+//
+//	# rules, in order
+//	- phase: Ready
+//	  match:
+//	    all:
+//	      - condition: Available
+//	        status: [True]
+//	      - any:
+//	          - condition: Degraded
+//	            notStatus: [True]
+//	          - condition: Degraded
+//	            reason: [Ignored]
+type RuleSpec struct {
+	Phase    string      `json:"phase"`
+	Priority int         `json:"priority,omitempty"`
+	Match    MatcherSpec `json:"match"`
+
+	// Reason and Message are the rule's summary, see WithSummary.
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// MatcherSpec is the declarative form of a condition matcher. Exactly one of All, Any, AtLeast or Condition is set;
+// a Condition takes exactly one of Status (ConditionEquals), NotStatus (ConditionNotEquals) or Reason
+// (ConditionReasonIs), or a single Status with Reason (ConditionReasonEquals). AtLeast is the quorum over the
+// matchers in Of.
+type MatcherSpec struct {
+	All []MatcherSpec `json:"all,omitempty"`
+	Any []MatcherSpec `json:"any,omitempty"`
+
+	AtLeast *int          `json:"atLeast,omitempty"`
+	Of      []MatcherSpec `json:"of,omitempty"`
+
+	Condition string   `json:"condition,omitempty"`
+	Status    Statuses `json:"status,omitempty"`
+	NotStatus Statuses `json:"notStatus,omitempty"`
+	Reason    []string `json:"reason,omitempty"`
+}
+
+// Statuses are the condition statuses of a MatcherSpec. Unquoted True and False, booleans to YAML, are accepted too.
+type Statuses []metav1.ConditionStatus
+
+func (s *Statuses) UnmarshalJSON(data []byte) error {
+	var raw []any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	statuses := make(Statuses, 0, len(raw))
+
+	for _, value := range raw {
+		switch v := value.(type) {
+		case string:
+			statuses = append(statuses, metav1.ConditionStatus(v))
+		case bool:
+			if v {
+				statuses = append(statuses, metav1.ConditionTrue)
+			} else {
+				statuses = append(statuses, metav1.ConditionFalse)
+			}
+		default:
+			return fmt.Errorf("status %v is not a string", value)
+		}
+	}
+
+	*s = statuses
+
+	return nil
+}
+
+// LoadRules reads phase rules from their YAML or JSON form, a list of RuleSpec, in order.
+// It returns every problem found, such as empty phases or unknown statuses, joined in a single error.
+func LoadRules(data []byte) ([]PhaseRule, error) {
+	var specs []RuleSpec
+	if err := yaml.UnmarshalStrict(data, &specs); err != nil {
+		return nil, fmt.Errorf("decoding rules: %w", err)
+	}
+
+	return RulesFromSpecs(specs)
+}
+
+// LoadAndMerge reads phase rules from several YAML or JSON sources, e.g. a base and overrides kept in different
+// ConfigMaps, and merges them in order: the rules a source has for a phase replace that phase's rules from the
+// sources before it, in the place of the first, the last source wins. Phases new to a source come after the others.
+// Problems are reported as for LoadRules, prefixed with their source, e.g. sources[1].rules[0].
+func LoadAndMerge(sources ...[]byte) ([]PhaseRule, error) {
+	var merged []PhaseRule
+
+	var errs []error
+
+	for i, data := range sources {
+		var specs []RuleSpec
+		if err := yaml.UnmarshalStrict(data, &specs); err != nil {
+			errs = append(errs, fmt.Errorf("sources[%d]: decoding rules: %w", i, err))
+			continue
+		}
+
+		rules, err := rulesFromSpecs(specs, fmt.Sprintf("sources[%d].rules", i))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		merged = mergeRules(merged, rules)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return merged, nil
+}
+
+// mergeRules returns base with the rules of every phase in overrides replaced by the overrides' rules.
+func mergeRules(base, overrides []PhaseRule) []PhaseRule {
+	overridden := sets.New[string]()
+	for _, rule := range overrides {
+		overridden.Insert(rule.Phase())
+	}
+
+	merged := make([]PhaseRule, 0, len(base)+len(overrides))
+	placed := sets.New[string]()
+
+	for _, rule := range base {
+		phase := rule.Phase()
+
+		if !overridden.Has(phase) {
+			merged = append(merged, rule)
+			continue
+		}
+
+		if placed.Has(phase) {
+			continue
+		}
+
+		placed.Insert(phase)
+
+		for _, override := range overrides {
+			if override.Phase() == phase {
+				merged = append(merged, override)
+			}
+		}
+	}
+
+	for _, override := range overrides {
+		if !placed.Has(override.Phase()) {
+			merged = append(merged, override)
+		}
+	}
+
+	return merged
+}
+
+// RulesFromSpecs builds phase rules from their declarative form, see LoadRules.
+func RulesFromSpecs(specs []RuleSpec) ([]PhaseRule, error) {
+	return rulesFromSpecs(specs, "rules")
+}
+
+func rulesFromSpecs(specs []RuleSpec, root string) ([]PhaseRule, error) {
+	rules := make([]PhaseRule, 0, len(specs))
+
+	var errs []error
+
+	for i, spec := range specs {
+		path := fmt.Sprintf("%s[%d]", root, i)
+
+		if spec.Phase == "" {
+			errs = append(errs, fmt.Errorf("%s: phase is empty", path))
+		}
+
+		matcher, err := spec.Match.matcher(path + ".match")
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var opts []RuleOption
+		if spec.Reason != "" || spec.Message != "" {
+			opts = append(opts, WithSummary(spec.Reason, spec.Message))
+		}
+
+		rules = append(rules, NewPhaseRuleWithPriority(spec.Phase, spec.Priority, matcher, opts...))
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return rules, nil
+}
+
+func (s MatcherSpec) matcher(path string) (ConditionMatcher, error) {
+	set := 0
+	for _, isSet := range []bool{s.All != nil, s.Any != nil, s.AtLeast != nil, s.Condition != ""} {
+		if isSet {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return nil, fmt.Errorf("%s: exactly one of all, any, atLeast or condition must be set", path)
+	}
+
+	if s.Of != nil && s.AtLeast == nil {
+		return nil, fmt.Errorf("%s: of is only valid with atLeast", path)
+	}
+
+	switch {
+	case s.All != nil:
+		matchers, err := matchersFromSpecs(s.All, path+".all")
+		return ConditionsAll(matchers...), err
+	case s.Any != nil:
+		matchers, err := matchersFromSpecs(s.Any, path+".any")
+		return ConditionsAny(matchers...), err
+	case s.AtLeast != nil:
+		matchers, err := matchersFromSpecs(s.Of, path+".of")
+		return ConditionsAtLeast(*s.AtLeast, matchers...), err
+	}
+
+	if s.Status != nil && s.Reason != nil && s.NotStatus == nil {
+		if len(s.Status) != 1 {
+			return nil, fmt.Errorf("%s: condition %q with a reason takes a single status", path, s.Condition)
+		}
+		if err := validateStatuses(s.Status, path+".status"); err != nil {
+			return nil, err
+		}
+		return ConditionReasonEquals(s.Condition, s.Status[0], s.Reason...), nil
+	}
+
+	set = 0
+	for _, isSet := range []bool{s.Status != nil, s.NotStatus != nil, s.Reason != nil} {
+		if isSet {
+			set++
+		}
+	}
+
+	if set != 1 {
+		return nil, fmt.Errorf("%s: condition %q needs exactly one of status, notStatus or reason, or a status with a reason", path, s.Condition)
+	}
+
+	switch {
+	case s.Status != nil:
+		if err := validateStatuses(s.Status, path+".status"); err != nil {
+			return nil, err
+		}
+		return ConditionEquals(s.Condition, s.Status...), nil
+	case s.NotStatus != nil:
+		if err := validateStatuses(s.NotStatus, path+".notStatus"); err != nil {
+			return nil, err
+		}
+		return ConditionNotEquals(s.Condition, s.NotStatus...), nil
+	default:
+		return ConditionReasonIs(s.Condition, s.Reason...), nil
+	}
+}
+
+func matchersFromSpecs(specs []MatcherSpec, path string) ([]ConditionMatcher, error) {
+	matchers := make([]ConditionMatcher, 0, len(specs))
+
+	var errs []error
+
+	for i, spec := range specs {
+		matcher, err := spec.matcher(fmt.Sprintf("%s[%d]", path, i))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, errors.Join(errs...)
+}
+
+func validateStatuses(statuses Statuses, path string) error {
+	if len(statuses) == 0 {
+		return fmt.Errorf("%s: no statuses", path)
+	}
+
+	for _, status := range statuses {
+		switch status {
+		case metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown:
+		default:
+			return fmt.Errorf("%s: unknown status %q, want True, False or Unknown", path, status)
+		}
+	}
+
+	return nil
+}
+
+// DumpRules writes phase rules in their YAML form, which LoadRules reads back.
+// Rules built with NewPhaseRule or NewPhaseRuleWithPriority over ConditionEquals, ConditionNotEquals,
+// ConditionReasonIs, ConditionReasonEquals, ConditionsAll, ConditionsAny and ConditionsAtLeast are supported,
+// others return an error, as do matchers LoadRules would reject: a condition without statuses or reasons, or
+// ConditionsAll and ConditionsAny without matchers.
+func DumpRules(rules []PhaseRule) ([]byte, error) {
+	specs, err := SpecsFromRules(rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(specs)
+}
+
+// SpecsFromRules returns the declarative form of phase rules, see DumpRules.
+func SpecsFromRules(rules []PhaseRule) ([]RuleSpec, error) {
+	specs := make([]RuleSpec, 0, len(rules))
+
+	for _, rule := range rules {
+		simple, ok := rule.(*phaseRuleSimple)
+		if !ok {
+			return nil, fmt.Errorf("unsupported rule %T for phase %q", rule, rule.Phase())
+		}
+
+		match, err := specFromMatcher(simple.matcher)
+		if err != nil {
+			return nil, fmt.Errorf("rule for phase %q: %w", rule.Phase(), err)
+		}
+
+		specs = append(specs, RuleSpec{
+			Phase:    simple.phase,
+			Priority: simple.priority,
+			Match:    match,
+			Reason:   simple.summaryReason,
+			Message:  simple.summaryMessage,
+		})
+	}
+
+	return specs, nil
+}
+
+// specFromMatcher returns the declarative form of matcher. Empty lists are omitted from it, so the matchers whose
+// form would need one to be read back are rejected.
+func specFromMatcher(matcher ConditionMatcher) (MatcherSpec, error) {
+	switch m := matcher.(type) {
+	case *conditionEqualsMatcher:
+		if len(m.statuses) == 0 {
+			return MatcherSpec{}, fmt.Errorf("condition %q has no statuses", m.condition)
+		}
+		return MatcherSpec{Condition: m.condition, Status: Statuses(m.statuses)}, nil
+	case *conditionNotEqualsMatcher:
+		if len(m.statuses) == 0 {
+			return MatcherSpec{}, fmt.Errorf("condition %q has no statuses", m.condition)
+		}
+		return MatcherSpec{Condition: m.condition, NotStatus: Statuses(m.statuses)}, nil
+	case *conditionReasonMatcher:
+		if len(m.reasons) == 0 {
+			return MatcherSpec{}, fmt.Errorf("condition %q has no reasons", m.condition)
+		}
+		return MatcherSpec{Condition: m.condition, Reason: m.reasons}, nil
+	case *conditionReasonEqualsMatcher:
+		if len(m.reasons) == 0 {
+			return MatcherSpec{}, fmt.Errorf("condition %q has no reasons", m.condition)
+		}
+		return MatcherSpec{Condition: m.condition, Status: Statuses{m.status}, Reason: m.reasons}, nil
+	case *conditionMatcherAll:
+		if len(m.matcherReferences) == 0 {
+			return MatcherSpec{}, errors.New("all has no matchers")
+		}
+		all, err := specsFromMatchers(m.matcherReferences)
+		return MatcherSpec{All: all}, err
+	case *conditionMatcherAny:
+		if len(m.matcherReferences) == 0 {
+			return MatcherSpec{}, errors.New("any has no matchers")
+		}
+		anyOf, err := specsFromMatchers(m.matcherReferences)
+		return MatcherSpec{Any: anyOf}, err
+	case *conditionMatcherAtLeast:
+		of, err := specsFromMatchers(m.matcherReferences)
+		return MatcherSpec{AtLeast: &m.n, Of: of}, err
+	default:
+		return MatcherSpec{}, fmt.Errorf("unsupported matcher %T", matcher)
+	}
+}
+
+func specsFromMatchers(matchers []ConditionMatcher) ([]MatcherSpec, error) {
+	specs := make([]MatcherSpec, 0, len(matchers))
+
+	for _, matcher := range matchers {
+		spec, err := specFromMatcher(matcher)
+		if err != nil {
+			return nil, err
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
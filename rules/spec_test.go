@@ -0,0 +1,281 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const specYAML = `
+- phase: Ready
+  match:
+    all:
+      - condition: A
+        status: [True]
+      - any:
+          - condition: B
+            notStatus: ["False"]
+          - condition: B
+            reason: [Ignored]
+- phase: BackingOff
+  match:
+    condition: A
+    status: [False]
+    reason: [Backoff]
+- phase: Failed
+  priority: 1
+  reason: QuorumLost
+  message: two or more components are down
+  match:
+    atLeast: 2
+    of:
+      - condition: A
+        status: [False, Unknown]
+      - condition: B
+        status: [False]
+      - condition: C
+        status: [False]
+`
+
+func TestLoadRules_YAML(t *testing.T) {
+	rules, err := LoadRules([]byte(specYAML))
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+
+	if len(rules) != 3 || rules[2].Priority() != 1 {
+		t.Fatalf("LoadRules() = %v, want three rules, the last with priority 1", rules)
+	}
+
+	tests := []struct {
+		name  string
+		conds []metav1.Condition
+		want  string
+	}{
+		{"ready", []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue)}, "Ready"},
+		{"ready by reason", []metav1.Condition{cond("A", metav1.ConditionTrue), {Type: "B", Status: metav1.ConditionFalse, Reason: "Ignored"}}, "Ready"},
+		{"failed takes priority", []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse), cond("C", metav1.ConditionFalse)}, "Failed"},
+		{"backing off", []metav1.Condition{{Type: "A", Status: metav1.ConditionFalse, Reason: "Backoff"}}, "BackingOff"},
+		{"neither", []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse)}, PhaseUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewPhaseComputer(rules...).Compute(&tt.conds); got != tt.want {
+				t.Errorf("Compute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRules_JSON(t *testing.T) {
+	rules, err := LoadRules([]byte(`[{"phase": "Ready", "match": {"condition": "A", "status": ["True"]}}]`))
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue)}
+	if got := NewPhaseComputer(rules...).Compute(&conds); got != "Ready" {
+		t.Errorf("Compute() = %q, want %q", got, "Ready")
+	}
+}
+
+func TestDumpRules_RoundTrip(t *testing.T) {
+	rules, err := LoadRules([]byte(specYAML))
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+
+	dumped, err := DumpRules(rules)
+	if err != nil {
+		t.Fatalf("DumpRules() error = %v", err)
+	}
+
+	reloaded, err := LoadRules(dumped)
+	if err != nil {
+		t.Fatalf("LoadRules() of the dump error = %v\n%s", err, dumped)
+	}
+
+	again, err := DumpRules(reloaded)
+	if err != nil {
+		t.Fatalf("DumpRules() error = %v", err)
+	}
+
+	if string(again) != string(dumped) {
+		t.Errorf("round trip changed the rules:\n%s\nthen\n%s", dumped, again)
+	}
+}
+
+func TestLoadRules_Summary(t *testing.T) {
+	rules, err := LoadRules([]byte(specYAML))
+	if err != nil {
+		t.Fatalf("LoadRules() error = %v", err)
+	}
+
+	if reason, message := rules[2].(Summarizer).Summary(); reason != "QuorumLost" || message != "two or more components are down" {
+		t.Errorf("Summary() = %q, %q, want the rule's reason and message", reason, message)
+	}
+	if reason, message := rules[0].(Summarizer).Summary(); reason != "" || message != "" {
+		t.Errorf("Summary() = %q, %q, want none", reason, message)
+	}
+}
+
+func TestDumpRules_Unsupported(t *testing.T) {
+	ready := NewPhaseRule("Ready", ConditionEquals("A", metav1.ConditionTrue))
+
+	if _, err := DumpRules([]PhaseRule{Negate(ready, "NotReady")}); err == nil {
+		t.Error("expected an error for a negated rule")
+	}
+	if _, err := DumpRules([]PhaseRule{NewPhaseRule("Settled", ConditionPrefixUniform("dependency/"))}); err == nil {
+		t.Error("expected an error for ConditionPrefixUniform")
+	}
+}
+
+func TestDumpRules_OnlyLoadable(t *testing.T) {
+	tests := []struct {
+		name    string
+		matcher ConditionMatcher
+		wantErr bool
+	}{
+		{"empty all", ConditionsAll(), true},
+		{"empty any", ConditionsAny(), true},
+		{"nested empty any", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue), ConditionsAny()), true},
+		{"equals without statuses", ConditionEquals("A"), true},
+		{"not equals without statuses", ConditionNotEquals("A"), true},
+		{"reason without reasons", ConditionReasonIs("A"), true},
+		{"status and reason without reasons", ConditionReasonEquals("A", metav1.ConditionFalse), true},
+		{"at least of none", ConditionsAtLeast(0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dumped, err := DumpRules([]PhaseRule{NewPhaseRule("Ready", tt.matcher)})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DumpRules() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if _, err := LoadRules(dumped); err != nil {
+				t.Errorf("LoadRules() of the dump error = %v\n%s", err, dumped)
+			}
+		})
+	}
+}
+
+func TestLoadRules_Invalid(t *testing.T) {
+	_, err := LoadRules([]byte(`
+- phase: ""
+  match:
+    condition: A
+    status: [Truee]
+- phase: Ready
+  match:
+    all:
+      - condition: A
+        status: [True, False]
+        reason: [Done]
+      - any: []
+        condition: B
+      - condition: C
+        status: [True]
+        notStatus: [False]
+`))
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+
+	for _, want := range []string{
+		"rules[0]: phase is empty",
+		`rules[0].match.status: unknown status "Truee"`,
+		"rules[1].match.all[0]: condition \"A\" with a reason takes a single status",
+		"rules[1].match.all[1]: exactly one of all, any, atLeast or condition must be set",
+		"rules[1].match.all[2]: condition \"C\" needs exactly one of status, notStatus or reason, or a status with a reason",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error is missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestLoadAndMerge_Override(t *testing.T) {
+	base := `
+- phase: Ready
+  match:
+    condition: A
+    status: [True]
+- phase: Failed
+  match:
+    condition: A
+    status: [False]
+`
+	override := `
+- phase: Degraded
+  match:
+    condition: B
+    status: [False]
+- phase: Ready
+  match:
+    all:
+      - condition: A
+        status: [True]
+      - condition: B
+        status: [True]
+`
+
+	rules, err := LoadAndMerge([]byte(base), []byte(override))
+	if err != nil {
+		t.Fatalf("LoadAndMerge() error = %v", err)
+	}
+
+	var phases []string
+	for _, rule := range rules {
+		phases = append(phases, rule.Phase())
+	}
+	if got, want := strings.Join(phases, ","), "Ready,Failed,Degraded"; got != want {
+		t.Fatalf("LoadAndMerge() phases = %s, want %s", got, want)
+	}
+
+	computer := NewPhaseComputer(rules...)
+
+	conds := []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionFalse)}
+	if got := computer.Compute(&conds); got != "Degraded" {
+		t.Errorf("Compute() = %q, want %q, Ready is overridden to need B", got, "Degraded")
+	}
+
+	conds = []metav1.Condition{cond("A", metav1.ConditionTrue), cond("B", metav1.ConditionTrue)}
+	if got := computer.Compute(&conds); got != "Ready" {
+		t.Errorf("Compute() = %q, want %q", got, "Ready")
+	}
+}
+
+func TestLoadAndMerge_Invalid(t *testing.T) {
+	_, err := LoadAndMerge(
+		[]byte(`[{"phase": "Ready", "match": {"condition": "A", "status": ["True"]}}]`),
+		[]byte(`[{"phase": "Ready", "match": {"condition": "A", "status": ["Truee"]}}]`),
+		[]byte(`{"phase": "Ready"}`),
+	)
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+
+	for _, want := range []string{
+		`sources[1].rules[0].match.status: unknown status "Truee"`,
+		"sources[2]: decoding rules",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error is missing %q:\n%v", want, err)
+		}
+	}
+	if strings.Contains(err.Error(), "sources[0]") {
+		t.Errorf("error blames the valid source:\n%v", err)
+	}
+}
+
+func TestLoadRules_UnknownField(t *testing.T) {
+	if _, err := LoadRules([]byte(`[{"phase": "Ready", "match": {"condition": "A", "statuses": ["True"]}}]`)); err == nil {
+		t.Error("expected an error for the unknown field statuses")
+	}
+}
@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// StateMachine describes phases as the states of a state machine, each entered when its conditions hold.
+type StateMachine struct {
+	// Initial is the state objects start in.
+	Initial string
+
+	States []State
+
+	// Fallback, if set, is the phase of a final rule satisfied whenever no state is, e.g. "Failed".
+	Fallback string
+}
+
+// State is a phase along with the conditions required to enter it.
+type State struct {
+	Name string
+
+	// Entry matches the conditions required to be in this state.
+	Entry ConditionMatcher
+
+	// Next lists the states this state can transition to.
+	Next []string
+
+	// Terminal marks a state with no way out, e.g. "Completed".
+	Terminal bool
+}
+
+// FromStateMachine translates a state machine into ordered phase rules.
+// States further away from the initial state take precedence, so an object that meets the entry conditions of
+// both Running and Completed is Completed; states at the same distance keep their declaration order.
+// The fallback rule, if any, comes last.
+//
+// The state machine must have unique, non-empty state names with entry conditions, transitions to known states,
+// every state reachable from the initial state and at least one terminal state.
+func FromStateMachine(def StateMachine) ([]PhaseRule, error) {
+	if err := def.validate(); err != nil {
+		return nil, err
+	}
+
+	distances := def.distances()
+
+	states := slices.Clone(def.States)
+	slices.SortStableFunc(states, func(a, b State) int {
+		return distances[b.Name] - distances[a.Name]
+	})
+
+	rules := make([]PhaseRule, 0, len(states)+1)
+
+	for _, state := range states {
+		rules = append(rules, NewPhaseRule(state.Name, state.Entry))
+	}
+
+	if def.Fallback != "" {
+		rules = append(rules, NewPhaseRule(def.Fallback, ConditionsAll()))
+	}
+
+	return rules, nil
+}
+
+func (def StateMachine) validate() error {
+	var errs []error
+
+	names := sets.New[string]()
+
+	for _, state := range def.States {
+		if state.Name == "" {
+			errs = append(errs, errors.New("state with empty name"))
+			continue
+		}
+
+		if names.Has(state.Name) {
+			errs = append(errs, fmt.Errorf("duplicate state %q", state.Name))
+		}
+
+		names.Insert(state.Name)
+
+		if state.Entry == nil {
+			errs = append(errs, fmt.Errorf("state %q has no entry conditions", state.Name))
+		}
+	}
+
+	for _, state := range def.States {
+		for _, next := range state.Next {
+			if !names.Has(next) {
+				errs = append(errs, fmt.Errorf("state %q transitions to unknown state %q", state.Name, next))
+			}
+		}
+	}
+
+	if !names.Has(def.Initial) {
+		errs = append(errs, fmt.Errorf("initial state %q is not a state", def.Initial))
+	}
+
+	if names.Has(def.Fallback) {
+		errs = append(errs, fmt.Errorf("fallback %q is already a state", def.Fallback))
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	distances := def.distances()
+	terminalReachable := false
+
+	for _, state := range def.States {
+		if _, ok := distances[state.Name]; !ok {
+			errs = append(errs, fmt.Errorf("state %q is not reachable from %q", state.Name, def.Initial))
+		} else if state.Terminal {
+			terminalReachable = true
+		}
+	}
+
+	if !terminalReachable {
+		errs = append(errs, fmt.Errorf("no terminal state is reachable from %q", def.Initial))
+	}
+
+	return errors.Join(errs...)
+}
+
+// distances returns the number of transitions from the initial state to every reachable state.
+func (def StateMachine) distances() map[string]int {
+	next := make(map[string][]string, len(def.States))
+	for _, state := range def.States {
+		next[state.Name] = state.Next
+	}
+
+	distances := map[string]int{def.Initial: 0}
+	queue := []string{def.Initial}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, state := range next[current] {
+			if _, seen := distances[state]; seen {
+				continue
+			}
+
+			distances[state] = distances[current] + 1
+			queue = append(queue, state)
+		}
+	}
+
+	return distances
+}
@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func jobStateMachine() StateMachine {
+	return StateMachine{
+		Initial: "Pending",
+		States: []State{
+			{
+				Name:  "Pending",
+				Entry: ConditionsAll(ConditionEquals("Scheduled", metav1.ConditionUnknown)),
+				Next:  []string{"Running"},
+			},
+			{
+				Name:  "Running",
+				Entry: ConditionsAll(ConditionEquals("Scheduled", metav1.ConditionTrue)),
+				Next:  []string{"Completed"},
+			},
+			{
+				Name:     "Completed",
+				Entry:    ConditionsAll(ConditionEquals("Completed", metav1.ConditionTrue)),
+				Terminal: true,
+			},
+		},
+		Fallback: "Failed",
+	}
+}
+
+func TestFromStateMachine_Order(t *testing.T) {
+	phaseRules, err := FromStateMachine(jobStateMachine())
+	if err != nil {
+		t.Fatalf("FromStateMachine() error = %v", err)
+	}
+
+	want := []string{"Completed", "Running", "Pending", "Failed"}
+	if len(phaseRules) != len(want) {
+		t.Fatalf("got %d rules, want %d", len(phaseRules), len(want))
+	}
+	for i, rule := range phaseRules {
+		if rule.Phase() != want[i] {
+			t.Errorf("rule %d phase = %q, want %q", i, rule.Phase(), want[i])
+		}
+	}
+}
+
+func TestFromStateMachine_Timeline(t *testing.T) {
+	phaseRules, err := FromStateMachine(jobStateMachine())
+	if err != nil {
+		t.Fatalf("FromStateMachine() error = %v", err)
+	}
+
+	computer := NewPhaseComputer(phaseRules...)
+
+	timeline := []struct {
+		conds []metav1.Condition
+		want  string
+	}{
+		{[]metav1.Condition{}, "Pending"},
+		{[]metav1.Condition{cond("Scheduled", metav1.ConditionTrue)}, "Running"},
+		{[]metav1.Condition{cond("Scheduled", metav1.ConditionTrue), cond("Completed", metav1.ConditionTrue)}, "Completed"},
+		{[]metav1.Condition{cond("Scheduled", metav1.ConditionFalse)}, "Failed"},
+	}
+
+	for i, step := range timeline {
+		if got := computer.Compute(&step.conds); got != step.want {
+			t.Errorf("step %d: phase = %q, want %q", i, got, step.want)
+		}
+	}
+}
+
+func TestFromStateMachine_Validation(t *testing.T) {
+	entry := ConditionsAll()
+
+	tests := []struct {
+		name string
+		def  StateMachine
+	}{
+		{"duplicate state", StateMachine{Initial: "A", States: []State{
+			{Name: "A", Entry: entry, Terminal: true},
+			{Name: "A", Entry: entry},
+		}}},
+		{"empty name", StateMachine{Initial: "A", States: []State{
+			{Name: "A", Entry: entry, Terminal: true, Next: []string{""}},
+			{Name: "", Entry: entry},
+		}}},
+		{"missing entry", StateMachine{Initial: "A", States: []State{{Name: "A", Terminal: true}}}},
+		{"unknown transition", StateMachine{Initial: "A", States: []State{
+			{Name: "A", Entry: entry, Terminal: true, Next: []string{"B"}},
+		}}},
+		{"unknown initial", StateMachine{Initial: "Z", States: []State{{Name: "A", Entry: entry, Terminal: true}}}},
+		{"unreachable state", StateMachine{Initial: "A", States: []State{
+			{Name: "A", Entry: entry, Terminal: true},
+			{Name: "B", Entry: entry},
+		}}},
+		{"no reachable terminal", StateMachine{Initial: "A", States: []State{
+			{Name: "A", Entry: entry, Next: []string{"B"}},
+			{Name: "B", Entry: entry, Next: []string{"A"}},
+		}}},
+		{"fallback is a state", StateMachine{Initial: "A", Fallback: "A", States: []State{
+			{Name: "A", Entry: entry, Terminal: true},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromStateMachine(tt.def); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RuleProblem is a problem Validate found with one rule of a rule set.
+type RuleProblem struct {
+	// Index is the position of the rule in the rules passed to Validate.
+	Index int
+	Phase string
+
+	Problem string
+
+	// Warning marks problems that leave the rule set usable but likely not as intended,
+	// e.g. a rule that is never satisfied or never wins.
+	Warning bool
+}
+
+func (p RuleProblem) Error() string {
+	return fmt.Sprintf("rules[%d] (phase %q): %s", p.Index, p.Phase, p.Problem)
+}
+
+// ValidationError holds every problem Validate found with a rule set.
+type ValidationError struct {
+	Problems []RuleProblem
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.Error()
+		if problem.Warning {
+			messages[i] = "warning: " + messages[i]
+		}
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Problems))
+	for i, problem := range e.Problems {
+		errs[i] = problem
+	}
+
+	return errs
+}
+
+// HasErrors reports whether any of the problems is more than a warning.
+func (e *ValidationError) HasErrors() bool {
+	return slices.ContainsFunc(e.Problems, func(problem RuleProblem) bool {
+		return !problem.Warning
+	})
+}
+
+// Validate checks a rule set for empty phases, phases named PhaseUnknown and phases used by more than one rule.
+// It also warns about rules of ConditionsAll over ConditionEquals that are never satisfied, requiring two statuses
+// of one condition, and about such rules shadowed by a rule evaluated before them that is satisfied whenever they are.
+// It returns a *ValidationError holding every problem found, nil if there are none.
+func Validate(rules []PhaseRule) error {
+	var problems []RuleProblem
+
+	firstByPhase := make(map[string]int)
+
+	for i, rule := range rules {
+		phase := rule.Phase()
+
+		switch phase {
+		case "":
+			problems = append(problems, RuleProblem{Index: i, Phase: phase, Problem: "phase is empty"})
+		case PhaseUnknown:
+			problems = append(problems, RuleProblem{Index: i, Phase: phase, Problem: "phase is PhaseUnknown, the phase when no rule is satisfied"})
+		}
+
+		if first, ok := firstByPhase[phase]; ok {
+			problems = append(problems, RuleProblem{Index: i, Phase: phase, Problem: fmt.Sprintf("duplicate phase, also rules[%d]", first)})
+		} else {
+			firstByPhase[phase] = i
+		}
+	}
+
+	// the evaluation order, by priority, then in order
+	order := make([]int, len(rules))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(rules[b].Priority(), rules[a].Priority())
+	})
+
+	requirements := make(map[int]map[string][]metav1.ConditionStatus)
+
+	for _, i := range order {
+		required, ok := equalsRequirements(rules[i])
+		if !ok {
+			continue
+		}
+
+		if conditionType, never := unsatisfiable(required); never {
+			problems = append(problems, RuleProblem{Index: i, Phase: rules[i].Phase(), Warning: true,
+				Problem: fmt.Sprintf("never satisfied, condition %s can't have all the required statuses", conditionType)})
+			continue
+		}
+
+		for _, earlier := range order {
+			if earlier == i {
+				break
+			}
+
+			if shadowing, ok := requirements[earlier]; ok && implies(required, shadowing) {
+				problems = append(problems, RuleProblem{Index: i, Phase: rules[i].Phase(), Warning: true,
+					Problem: fmt.Sprintf("never wins, rules[%d] (phase %q) is evaluated first and satisfied whenever it is", earlier, rules[earlier].Phase())})
+				break
+			}
+		}
+
+		requirements[i] = required
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	slices.SortStableFunc(problems, func(a, b RuleProblem) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	return &ValidationError{Problems: problems}
+}
+
+// equalsRequirements returns the statuses each condition type must have for rule to be satisfied, if the rule is
+// a ConditionEquals or a ConditionsAll of them, nested or not. A condition type required more than once must have
+// a status allowed by all of them.
+func equalsRequirements(rule PhaseRule) (map[string][]metav1.ConditionStatus, bool) {
+	simple, ok := rule.(*phaseRuleSimple)
+	if !ok {
+		return nil, false
+	}
+
+	required := make(map[string][]metav1.ConditionStatus)
+
+	return required, collectEqualsRequirements(simple.matcher, required)
+}
+
+func collectEqualsRequirements(matcher ConditionMatcher, required map[string][]metav1.ConditionStatus) bool {
+	switch m := matcher.(type) {
+	case *conditionEqualsMatcher:
+		statuses, ok := required[m.condition]
+		if !ok {
+			required[m.condition] = slices.Clone(m.statuses)
+			return true
+		}
+
+		required[m.condition] = slices.DeleteFunc(statuses, func(status metav1.ConditionStatus) bool {
+			return !slices.Contains(m.statuses, status)
+		})
+
+		return true
+	case *conditionMatcherAll:
+		for _, child := range m.matcherReferences {
+			if !collectEqualsRequirements(child, required) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// unsatisfiable returns a condition type no status satisfies, if any.
+func unsatisfiable(required map[string][]metav1.ConditionStatus) (string, bool) {
+	for _, conditionType := range slices.Sorted(maps.Keys(required)) {
+		if len(required[conditionType]) == 0 {
+			return conditionType, true
+		}
+	}
+
+	return "", false
+}
+
+// implies reports whether conditions meeting required always meet other.
+func implies(required, other map[string][]metav1.ConditionStatus) bool {
+	for conditionType, allowed := range other {
+		statuses, ok := required[conditionType]
+		if !ok {
+			return false
+		}
+
+		for _, status := range statuses {
+			if !slices.Contains(allowed, status) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
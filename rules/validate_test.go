@@ -0,0 +1,87 @@
+package rules
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidate_Valid(t *testing.T) {
+	if err := Validate(milestoneRules()); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestValidate_Problems(t *testing.T) {
+	err := Validate([]PhaseRule{
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionTrue))),
+		NewPhaseRule("", ConditionEquals("A", metav1.ConditionUnknown)),
+		NewPhaseRule(PhaseUnknown, ConditionEquals("B", metav1.ConditionFalse)),
+		NewPhaseRule("Ready", ConditionsAll(ConditionEquals("A", metav1.ConditionFalse), ConditionEquals("B", metav1.ConditionTrue))),
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+	}
+
+	if !validationErr.HasErrors() {
+		t.Error("HasErrors() = false, want true")
+	}
+
+	want := []string{
+		`rules[1] (phase ""): phase is empty`,
+		`rules[2] (phase "Unknown"): phase is PhaseUnknown`,
+		`rules[3] (phase "Ready"): duplicate phase, also rules[0]`,
+	}
+	if len(validationErr.Problems) != len(want) {
+		t.Fatalf("got problems %v, want %d", validationErr.Problems, len(want))
+	}
+	for i, problem := range validationErr.Problems {
+		if !strings.HasPrefix(problem.Error(), want[i]) || problem.Warning {
+			t.Errorf("problem %d = %q, want an error starting with %q", i, problem.Error(), want[i])
+		}
+	}
+}
+
+func TestValidate_Warnings(t *testing.T) {
+	err := Validate([]PhaseRule{
+		NewPhaseRule("Progressing", ConditionsAll(ConditionEquals("Scheduled", metav1.ConditionTrue))),
+		NewPhaseRule("Ready", ConditionsAll(
+			ConditionEquals("Scheduled", metav1.ConditionTrue),
+			ConditionEquals("Healthy", metav1.ConditionTrue),
+		)),
+		NewPhaseRule("Broken", ConditionsAll(
+			ConditionEquals("Healthy", metav1.ConditionTrue),
+			ConditionEquals("Healthy", metav1.ConditionFalse),
+		)),
+		NewPhaseRuleWithPriority("Deleting", 1, ConditionsAll(
+			ConditionEquals("Deleting", metav1.ConditionTrue),
+			ConditionEquals("Scheduled", metav1.ConditionTrue),
+		)),
+	})
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+	}
+
+	if validationErr.HasErrors() {
+		t.Errorf("HasErrors() = true, want only warnings: %v", err)
+	}
+
+	want := []string{
+		`rules[1] (phase "Ready"): never wins, rules[0] (phase "Progressing")`,
+		`rules[2] (phase "Broken"): never satisfied, condition Healthy`,
+	}
+	if len(validationErr.Problems) != len(want) {
+		t.Fatalf("got problems %v, want %d", validationErr.Problems, len(want))
+	}
+	for i, problem := range validationErr.Problems {
+		if !strings.HasPrefix(problem.Error(), want[i]) || !problem.Warning {
+			t.Errorf("problem %d = %q, want a warning starting with %q", i, problem.Error(), want[i])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package rules
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/debdutdeb/kubernetes-phase-rules/sets"
+)
+
+// WeightedMatcher is a matcher of ConditionsWeighted, contributing Weight when it matches.
+type WeightedMatcher struct {
+	Matcher ConditionMatcher
+	Weight  float64
+}
+
+type conditionMatcherWeighted struct {
+	threshold float64
+	weighted  []WeightedMatcher
+}
+
+var _ ConditionMatcher = (*conditionMatcherWeighted)(nil)
+
+func (m *conditionMatcherWeighted) Matches(conditions *[]metav1.Condition) MatchResult {
+	return m.matchesMissing(conditions, nil)
+}
+
+func (m *conditionMatcherWeighted) matchesMissing(conditions *[]metav1.Condition, missing sets.Set[string]) MatchResult {
+	if conditions == nil {
+		return MatcherUnknown
+	}
+
+	var matched, unknown float64
+
+	for _, weighted := range m.weighted {
+		switch matches(weighted.Matcher, conditions, missing) {
+		case MatcherMatched:
+			matched += weighted.Weight
+		case MatcherUnknown:
+			unknown += weighted.Weight
+		}
+	}
+
+	switch {
+	case matched >= m.threshold:
+		return MatcherMatched
+	case matched+unknown >= m.threshold:
+		// the absent conditions could still make up the threshold
+		return MatcherUnknown
+	default:
+		return MatcherNotMatched
+	}
+}
+
+func (m *conditionMatcherWeighted) ConditionTypes() sets.Set[string] {
+	types := sets.New[string]()
+
+	for _, weighted := range m.weighted {
+		types.DestructiveUnion(weighted.Matcher.ConditionTypes())
+	}
+
+	return types
+}
+
+// ConditionsWeighted returns a matcher for the weights of the matching matchers summing to threshold or more,
+// e.g. a "Healthy" phase once enough of the important conditions are True. It generalizes ConditionsAll, with the
+// total weight as threshold, and ConditionsAny, with the smallest weight. A matcher whose condition is missing
+// contributes nothing; in a phase rule the missing condition is evaluated as Unknown, like everywhere else.
+// Weights are expected to be non-negative, a threshold <= 0 matches any conditions.
+func ConditionsWeighted(threshold float64, weighted ...WeightedMatcher) ConditionMatcher {
+	return &conditionMatcherWeighted{
+		threshold: threshold,
+		weighted:  weighted,
+	}
+}
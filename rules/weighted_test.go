@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditionsWeighted_Threshold(t *testing.T) {
+	weighted := []WeightedMatcher{
+		{Matcher: ConditionEquals("Database", metav1.ConditionTrue), Weight: 3},
+		{Matcher: ConditionEquals("Cache", metav1.ConditionTrue), Weight: 1},
+		{Matcher: ConditionEquals("Search", metav1.ConditionTrue), Weight: 1.5},
+	}
+
+	conds := []metav1.Condition{
+		cond("Database", metav1.ConditionTrue),
+		cond("Cache", metav1.ConditionFalse),
+		cond("Search", metav1.ConditionTrue),
+	}
+
+	// Database and Search match, 4.5 of 5.5
+	tests := []struct {
+		threshold float64
+		want      MatchResult
+	}{
+		{0, MatcherMatched},
+		{4.5, MatcherMatched},
+		{4.6, MatcherNotMatched},
+		{5.5, MatcherNotMatched},
+	}
+
+	for _, tt := range tests {
+		if got := ConditionsWeighted(tt.threshold, weighted...).Matches(&conds); got != tt.want {
+			t.Errorf("threshold %v: Matches() = %v, want %v", tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestConditionsWeighted_Missing(t *testing.T) {
+	matcher := ConditionsWeighted(4,
+		WeightedMatcher{Matcher: ConditionEquals("Database", metav1.ConditionTrue), Weight: 3},
+		WeightedMatcher{Matcher: ConditionEquals("Cache", metav1.ConditionTrue), Weight: 1},
+	)
+	conds := []metav1.Condition{cond("Database", metav1.ConditionTrue)}
+
+	if got := matcher.Matches(&conds); got != MatcherUnknown {
+		t.Errorf("Matches() = %v, want %v while the missing Cache could make up the threshold", got, MatcherUnknown)
+	}
+
+	rule := NewPhaseRule("Healthy", matcher)
+	if rule.Satisfies(&conds) {
+		t.Error("expected the missing condition to contribute nothing")
+	}
+
+	conds = append(conds, cond("Cache", metav1.ConditionTrue))
+	if !rule.Satisfies(&conds) {
+		t.Error("expected true once the weights reach the threshold")
+	}
+}
+
+func TestConditionsWeighted_GeneralizesAllAndAny(t *testing.T) {
+	weighted := []WeightedMatcher{
+		{Matcher: ConditionEquals("A", metav1.ConditionTrue), Weight: 2},
+		{Matcher: ConditionEquals("B", metav1.ConditionTrue), Weight: 1},
+	}
+	matchers := []ConditionMatcher{weighted[0].Matcher, weighted[1].Matcher}
+
+	allRule := NewPhaseRule("All", ConditionsAll(matchers...))
+	anyRule := NewPhaseRule("Any", ConditionsAny(matchers...))
+	weightedAll := NewPhaseRule("All", ConditionsWeighted(3, weighted...))
+	weightedAny := NewPhaseRule("Any", ConditionsWeighted(1, weighted...))
+
+	statuses := []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, ""}
+
+	for _, a := range statuses {
+		for _, b := range statuses {
+			conds := []metav1.Condition{}
+			if a != "" {
+				conds = append(conds, cond("A", a))
+			}
+			if b != "" {
+				conds = append(conds, cond("B", b))
+			}
+
+			if allRule.Satisfies(&conds) != weightedAll.Satisfies(&conds) {
+				t.Errorf("A %q, B %q: weighted with the total weight differs from ConditionsAll", a, b)
+			}
+			if anyRule.Satisfies(&conds) != weightedAny.Satisfies(&conds) {
+				t.Errorf("A %q, B %q: weighted with the smallest weight differs from ConditionsAny", a, b)
+			}
+		}
+	}
+}
@@ -1,5 +1,13 @@
 package sets
 
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"iter"
+	"slices"
+)
+
 type Set[T comparable] map[T]struct{}
 
 func New[T comparable](items ...T) Set[T] {
@@ -14,6 +22,25 @@ func (s Set[T]) Insert(items ...T) {
 	}
 }
 
+// Delete removes items from the set, items not in it are ignored.
+func (s Set[T]) Delete(items ...T) {
+	for _, item := range items {
+		delete(s, item)
+	}
+}
+
+// Clear removes every item, in place.
+func (s Set[T]) Clear() {
+	clear(s)
+}
+
+// Clone returns a shallow copy of the set.
+func (s Set[T]) Clone() Set[T] {
+	result := make(Set[T], len(s))
+	result.DestructiveUnion(s)
+	return result
+}
+
 func (s Set[T]) Has(item T) bool {
 	_, ok := s[item]
 	return ok
@@ -39,6 +66,136 @@ func (s Set[T]) DestructiveUnion(other Set[T]) {
 	}
 }
 
+// Retain removes every item not in other, i.e. an in-place intersection.
+func (s Set[T]) Retain(other Set[T]) {
+	for item := range s {
+		if !other.Has(item) {
+			delete(s, item)
+		}
+	}
+}
+
+// Filter returns a new set of the items satisfying pred, s is left as it is.
+func (s Set[T]) Filter(pred func(T) bool) Set[T] {
+	result := New[T]()
+
+	for item := range s {
+		if pred(item) {
+			result.Insert(item)
+		}
+	}
+
+	return result
+}
+
+// Equal reports whether both sets hold exactly the same items.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// IsSubset reports whether every item of s is in other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	for item := range s {
+		if !other.Has(item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSuperset reports whether every item of other is in s.
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
 func (s Set[T]) Len() int {
 	return len(s)
 }
+
+// All returns an iterator over the items of the set, in unspecified order.
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range s {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// UnsortedSlice returns the items of the set in unspecified order.
+func (s Set[T]) UnsortedSlice() []T {
+	return slices.AppendSeq(make([]T, 0, len(s)), s.All())
+}
+
+// SortedSlice returns the items of the set in ascending order, e.g. for logs or assertions.
+func SortedSlice[T cmp.Ordered](s Set[T]) []T {
+	items := s.UnsortedSlice()
+	slices.Sort(items)
+
+	return items
+}
+
+// SortedSliceFunc returns the items of the set ordered by compare, for items that aren't cmp.Ordered.
+func SortedSliceFunc[T comparable](s Set[T], compare func(a, b T) int) []T {
+	items := s.UnsortedSlice()
+	slices.SortFunc(items, compare)
+
+	return items
+}
+
+// MarshalJSON encodes the set as a JSON array, [] for a nil set. The items are ordered by their encoding so the same
+// set always encodes the same, e.g. in a status field.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	items := make([][]byte, 0, len(s))
+
+	for item := range s {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, data)
+	}
+
+	slices.SortFunc(items, bytes.Compare)
+
+	return slices.Concat([]byte("["), bytes.Join(items, []byte(",")), []byte("]")), nil
+}
+
+// UnmarshalJSON decodes a JSON array into the set, replacing its items. Duplicates are dropped.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var items []T
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+
+	*s = New(items...)
+
+	return nil
+}
+
+// Map returns a new set of f applied to every item of s, e.g. to normalize condition types. Items mapping to the
+// same value collapse into one. It is a function rather than a method, methods can't have type parameters.
+func Map[T, U comparable](s Set[T], f func(T) U) Set[U] {
+	result := make(Set[U], len(s))
+
+	for item := range s {
+		result.Insert(f(item))
+	}
+
+	return result
+}
+
+// Reduce folds every item of the set into an accumulator, starting from init.
+// Iteration order is unspecified, so fn should not depend on it.
+func Reduce[T comparable, A any](s Set[T], init A, fn func(A, T) A) A {
+	acc := init
+
+	for item := range s {
+		acc = fn(acc, item)
+	}
+
+	return acc
+}
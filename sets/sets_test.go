@@ -0,0 +1,249 @@
+package sets
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReduce_Count(t *testing.T) {
+	s := New(1, 2, 3, 4)
+	sum := Reduce(s, 0, func(acc, item int) int { return acc + item })
+	if sum != 10 {
+		t.Errorf("Reduce() = %d, want 10", sum)
+	}
+}
+
+func TestReduce_Empty(t *testing.T) {
+	got := Reduce(New[string](), "init", func(acc, item string) string { return acc + item })
+	if got != "init" {
+		t.Errorf("Reduce() on empty set = %q, want %q", got, "init")
+	}
+}
+
+func TestReduce_SortedKey(t *testing.T) {
+	key := func(s Set[string]) string {
+		items := Reduce(s, []string{}, func(acc []string, item string) []string { return append(acc, item) })
+		slices.Sort(items)
+		return strings.Join(items, ",")
+	}
+
+	s := New("Ready", "Available", "Progressing")
+	want := "Available,Progressing,Ready"
+	for range 10 {
+		if got := key(s); got != want {
+			t.Fatalf("key = %q, want %q", got, want)
+		}
+	}
+	if got := key(New("Progressing", "Ready", "Available")); got != want {
+		t.Errorf("key of equal set built in another order = %q, want %q", got, want)
+	}
+}
+
+func TestRetain(t *testing.T) {
+	s := New("Ready", "Synced", "Degraded")
+	s.Retain(New("Synced", "Degraded", "Available"))
+
+	if s.Len() != 2 || !s.Has("Synced") || !s.Has("Degraded") {
+		t.Errorf("after Retain = %v, want {Synced, Degraded}", s)
+	}
+
+	s.Retain(New[string]())
+	if s.Len() != 0 {
+		t.Errorf("after Retain with empty set = %v, want empty", s)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	s := New("Ready", "DatabaseReady", "CacheReady", "Synced")
+
+	ready := s.Filter(func(conditionType string) bool { return strings.HasSuffix(conditionType, "Ready") })
+
+	if !ready.Equal(New("Ready", "DatabaseReady", "CacheReady")) {
+		t.Errorf("Filter() = %v, want the Ready conditions", SortedSlice(ready))
+	}
+	if s.Len() != 4 {
+		t.Errorf("Filter() modified the set: %v", SortedSlice(s))
+	}
+}
+
+func TestMap(t *testing.T) {
+	s := New("ready", "Ready", "synced")
+
+	normalized := Map(s, strings.ToUpper)
+
+	if !normalized.Equal(New("READY", "SYNCED")) {
+		t.Errorf("Map() = %v, want {READY, SYNCED}", SortedSlice(normalized))
+	}
+	if !s.Equal(New("ready", "Ready", "synced")) {
+		t.Errorf("Map() modified the set: %v", SortedSlice(s))
+	}
+
+	if lengths := Map(s, func(item string) int { return len(item) }); !lengths.Equal(New(5, 6)) {
+		t.Errorf("Map() = %v, want {5, 6}", SortedSlice(lengths))
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := New("Ready", "Synced", "Degraded")
+	s.Delete("Synced", "Available")
+
+	if s.Len() != 2 || s.Has("Synced") || !s.Has("Ready") || !s.Has("Degraded") {
+		t.Errorf("after Delete = %v, want {Ready, Degraded}", s)
+	}
+}
+
+func TestClear(t *testing.T) {
+	s := New("Ready", "Synced")
+	s.Clear()
+
+	if s.Len() != 0 {
+		t.Errorf("after Clear = %v, want empty", s)
+	}
+
+	s.Insert("Ready")
+	if !s.Has("Ready") {
+		t.Error("cleared set doesn't accept inserts")
+	}
+}
+
+func TestClone(t *testing.T) {
+	s := New("Ready", "Synced")
+	clone := s.Clone()
+	clone.Insert("Degraded")
+	clone.Delete("Ready")
+
+	if s.Len() != 2 || !s.Has("Ready") || s.Has("Degraded") {
+		t.Errorf("changing the clone changed the set: %v", s)
+	}
+	if clone.Len() != 2 || !clone.Has("Synced") || !clone.Has("Degraded") {
+		t.Errorf("clone = %v, want {Synced, Degraded}", clone)
+	}
+}
+
+func TestEqualAndSubsets(t *testing.T) {
+	tests := []struct {
+		name                    string
+		a, b                    Set[string]
+		equal, subset, superset bool
+	}{
+		{"same items, other order", New("Ready", "Synced"), New("Synced", "Ready"), true, true, true},
+		{"both empty", New[string](), New[string](), true, true, true},
+		{"smaller", New("Ready"), New("Ready", "Synced"), false, true, false},
+		{"larger", New("Ready", "Synced"), New("Ready"), false, false, true},
+		{"empty and not", New[string](), New("Ready"), false, true, false},
+		{"disjoint", New("Ready"), New("Synced"), false, false, false},
+		{"same size, different items", New("Ready", "Synced"), New("Ready", "Degraded"), false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.Equal(tt.b); got != tt.equal {
+				t.Errorf("Equal() = %v, want %v", got, tt.equal)
+			}
+			if got := tt.a.IsSubset(tt.b); got != tt.subset {
+				t.Errorf("IsSubset() = %v, want %v", got, tt.subset)
+			}
+			if got := tt.a.IsSuperset(tt.b); got != tt.superset {
+				t.Errorf("IsSuperset() = %v, want %v", got, tt.superset)
+			}
+		})
+	}
+}
+
+func TestSlices(t *testing.T) {
+	s := New("Synced", "Ready", "Available")
+
+	unsorted := s.UnsortedSlice()
+	slices.Sort(unsorted)
+	if want := []string{"Available", "Ready", "Synced"}; !slices.Equal(unsorted, want) {
+		t.Errorf("UnsortedSlice() = %v, want %v in any order", unsorted, want)
+	}
+
+	if got, want := SortedSlice(s), []string{"Available", "Ready", "Synced"}; !slices.Equal(got, want) {
+		t.Errorf("SortedSlice() = %v, want %v", got, want)
+	}
+
+	type version struct{ major, minor int }
+	versions := New(version{1, 2}, version{0, 9}, version{1, 0})
+	byVersion := func(a, b version) int {
+		if a.major != b.major {
+			return a.major - b.major
+		}
+		return a.minor - b.minor
+	}
+	if got, want := SortedSliceFunc(versions, byVersion), []version{{0, 9}, {1, 0}, {1, 2}}; !slices.Equal(got, want) {
+		t.Errorf("SortedSliceFunc() = %v, want %v", got, want)
+	}
+
+	if got := New[string]().UnsortedSlice(); got == nil || len(got) != 0 {
+		t.Errorf("UnsortedSlice() of empty set = %#v, want empty slice", got)
+	}
+}
+
+func TestAll(t *testing.T) {
+	got := slices.Sorted(New("Synced", "Ready", "Available").All())
+	if want := []string{"Available", "Ready", "Synced"}; !slices.Equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+
+	count := 0
+	for range New(1, 2, 3).All() {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("All() yielded %d items after break, want 1", count)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		set  Set[string]
+		want string
+	}{
+		{"items", New("Synced", "Ready", "Available"), `["Available","Ready","Synced"]`},
+		{"empty", New[string](), `[]`},
+		{"nil", nil, `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.set)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", data, tt.want)
+			}
+
+			var got Set[string]
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got == nil || !got.Equal(tt.set) {
+				t.Errorf("round trip = %v, want %v", got, tt.set)
+			}
+		})
+	}
+}
+
+func TestJSON_InStruct(t *testing.T) {
+	type status struct {
+		Watched Set[int] `json:"watched"`
+	}
+
+	var got status
+	if err := json.Unmarshal([]byte(`{"watched":[3,1,3,2]}`), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !got.Watched.Equal(New(1, 2, 3)) {
+		t.Errorf("Unmarshal() = %v, want {1, 2, 3}", got.Watched)
+	}
+
+	if err := json.Unmarshal([]byte(`{"watched":{"1":{}}}`), &got); err == nil {
+		t.Error("Unmarshal() of an object succeeded, want an error")
+	}
+}
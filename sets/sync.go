@@ -0,0 +1,66 @@
+package sets
+
+import "sync"
+
+// SyncSet is a Set safe for concurrent use, e.g. to collect condition types across goroutines.
+// Use a plain Set where only one goroutine touches it.
+type SyncSet[T comparable] struct {
+	mu  sync.RWMutex
+	set Set[T]
+}
+
+func NewSync[T comparable](items ...T) *SyncSet[T] {
+	return &SyncSet[T]{set: New(items...)}
+}
+
+func (s *SyncSet[T]) Insert(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set.Insert(items...)
+}
+
+func (s *SyncSet[T]) Delete(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set.Delete(items...)
+}
+
+func (s *SyncSet[T]) Has(item T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.Has(item)
+}
+
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.Len()
+}
+
+// Union returns a new plain Set with the items of s and other.
+func (s *SyncSet[T]) Union(other Set[T]) Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.Union(other)
+}
+
+// DestructiveUnion inserts the items of other into s.
+func (s *SyncSet[T]) DestructiveUnion(other Set[T]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set.DestructiveUnion(other)
+}
+
+// Snapshot returns a copy of the items as a plain Set, later changes to s don't affect it.
+func (s *SyncSet[T]) Snapshot() Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.Clone()
+}
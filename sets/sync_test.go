@@ -0,0 +1,52 @@
+package sets
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSyncSet_Concurrent(t *testing.T) {
+	s := NewSync[string]()
+
+	var wg sync.WaitGroup
+
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range 100 {
+				conditionType := fmt.Sprintf("Component%d/Ready%d", i, j)
+				s.Insert(conditionType)
+				if !s.Has(conditionType) {
+					t.Errorf("Has(%q) = false right after Insert", conditionType)
+				}
+				_ = s.Len()
+				_ = s.Union(New("Ready"))
+				s.DestructiveUnion(New("Ready"))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if got, want := s.Len(), 8*100+1; got != want {
+		t.Errorf("Len() = %d, want %d", got, want)
+	}
+}
+
+func TestSyncSet_Snapshot(t *testing.T) {
+	s := NewSync("Ready", "Synced")
+	snapshot := s.Snapshot()
+
+	s.Delete("Ready")
+	s.Insert("Degraded")
+
+	if !snapshot.Equal(New("Ready", "Synced")) {
+		t.Errorf("snapshot = %v, want it unaffected by later changes", snapshot)
+	}
+	if !s.Snapshot().Equal(New("Synced", "Degraded")) {
+		t.Errorf("set = %v, want {Synced, Degraded}", s.Snapshot())
+	}
+}